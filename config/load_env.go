@@ -1,6 +1,8 @@
 package config
 
 import (
+	"fmt"
+	"strings"
 	"time"
 
 	"github.com/spf13/viper"
@@ -18,8 +20,37 @@ type Config struct {
 	TokenSecret    string        `mapstructure:"TOKEN_SECRET"`
 	TokenExpiresIn time.Duration `mapstructure:"TOKEN_EXPIRED_IN"`
 	TokenMaxAge    int           `mapstructure:"TOKEN_MAXAGE"`
+
+	AdminName     string `mapstructure:"ADMIN_NAME"`
+	AdminEmail    string `mapstructure:"ADMIN_EMAIL"`
+	AdminPassword string `mapstructure:"ADMIN_PASSWORD"`
+
+	// TrashRetention is how long deleted reports are kept before they can
+	// be purged
+	TrashRetention time.Duration `mapstructure:"TRASH_RETENTION"`
+
+	// AppEnv is "development" on a developer's machine, where the server is
+	// reached over plain http
+	AppEnv string `mapstructure:"APP_ENV"`
+
+	// CookieSecure, when set, overrides whether the token cookie is only
+	// sent over https; see SecureCookies
+	CookieSecure *bool `mapstructure:"COOKIE_SECURE"`
+
+	// MigrateOnStart applies pending migrations before the server starts;
+	// otherwise they are applied with the migrate command
+	MigrateOnStart bool `mapstructure:"MIGRATE_ON_START"`
+
+	// Pagination sets the default and largest page size of lists
+	Pagination PaginationConfig `mapstructure:",squash"`
 }
 
+// DevelopmentEnv is the APP_ENV of local development
+const DevelopmentEnv = "development"
+
+// DefaultTrashRetention applies when TRASH_RETENTION is not set
+const DefaultTrashRetention = 30 * 24 * time.Hour
+
 func LoadConfig(path string) (config Config, err error) {
 	viper.AddConfigPath(path)
 	viper.SetConfigType("env")
@@ -27,6 +58,15 @@ func LoadConfig(path string) (config Config, err error) {
 
 	viper.AutomaticEnv()
 
+	// Secrets and per-deployment settings are kept out of app.env. Unmarshal
+	// only reads the environment for keys viper already knows, so name them
+	// here.
+	for _, key := range []string{"TOKEN_SECRET", "ADMIN_EMAIL", "ADMIN_PASSWORD", "APP_ENV", "COOKIE_SECURE"} {
+		if err = viper.BindEnv(key); err != nil {
+			return
+		}
+	}
+
 	err = viper.ReadInConfig()
 	if err != nil {
 		return
@@ -35,3 +75,68 @@ func LoadConfig(path string) (config Config, err error) {
 	err = viper.Unmarshal(&config)
 	return
 }
+
+// MinTokenSecretLength is the shortest TOKEN_SECRET accepted: 32 bytes for
+// the HMAC key that signs tokens
+const MinTokenSecretLength = 32
+
+// MinAdminPasswordLength is the shortest ADMIN_PASSWORD accepted
+const MinAdminPasswordLength = 12
+
+// placeholders are values copied from examples, which anyone could guess
+var placeholders = []string{
+	"my-ultra-secure-json-web-token-string",
+	"admin@example.com",
+	"change-me-please",
+	"changeme",
+	"secret",
+	"password",
+}
+
+// Validate refuses to run with a missing or guessable token secret or
+// admin login
+func (c *Config) Validate() error {
+	required := []struct {
+		key   string
+		value string
+	}{
+		{"TOKEN_SECRET", c.TokenSecret},
+		{"ADMIN_EMAIL", c.AdminEmail},
+		{"ADMIN_PASSWORD", c.AdminPassword},
+	}
+	for _, r := range required {
+		if strings.TrimSpace(r.value) == "" {
+			return fmt.Errorf("%s must be set in the environment", r.key)
+		}
+		if isPlaceholder(r.value) {
+			return fmt.Errorf("%s is a placeholder; set a value of your own", r.key)
+		}
+	}
+
+	if len(c.TokenSecret) < MinTokenSecretLength {
+		return fmt.Errorf("TOKEN_SECRET must be at least %d characters", MinTokenSecretLength)
+	}
+	if len(c.AdminPassword) < MinAdminPasswordLength {
+		return fmt.Errorf("ADMIN_PASSWORD must be at least %d characters", MinAdminPasswordLength)
+	}
+	return nil
+}
+
+// SecureCookies reports whether the token cookie is marked Secure: as
+// COOKIE_SECURE says, or else everywhere but in development
+func (c *Config) SecureCookies() bool {
+	if c.CookieSecure != nil {
+		return *c.CookieSecure
+	}
+	return c.AppEnv != DevelopmentEnv
+}
+
+func isPlaceholder(value string) bool {
+	value = strings.ToLower(strings.TrimSpace(value))
+	for _, placeholder := range placeholders {
+		if value == placeholder {
+			return true
+		}
+	}
+	return strings.HasSuffix(value, "@example.com")
+}
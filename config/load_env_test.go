@@ -0,0 +1,49 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestSecureCookies(t *testing.T) {
+	yes, no := true, false
+
+	tests := []struct {
+		name   string
+		config Config
+		want   bool
+	}{
+		{name: "nothing set", want: true},
+		{name: "production", config: Config{AppEnv: "production"}, want: true},
+		{name: "development", config: Config{AppEnv: DevelopmentEnv}, want: false},
+		{name: "turned off", config: Config{CookieSecure: &no}, want: false},
+		{name: "turned on in development", config: Config{AppEnv: DevelopmentEnv, CookieSecure: &yes}, want: true},
+	}
+
+	for _, tt := range tests {
+		if got := tt.config.SecureCookies(); got != tt.want {
+			t.Errorf("%s: SecureCookies() = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestLoadConfigCookieSecureFromEnvironment(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "app.env"), []byte("PORT=8080\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("APP_ENV", "production")
+	t.Setenv("COOKIE_SECURE", "false")
+
+	config, err := LoadConfig(dir)
+	if err != nil {
+		t.Fatalf("LoadConfig() error = %v", err)
+	}
+	if config.AppEnv != "production" || config.CookieSecure == nil || *config.CookieSecure {
+		t.Errorf("APP_ENV, COOKIE_SECURE = %q, %v, want production and false", config.AppEnv, config.CookieSecure)
+	}
+	if config.SecureCookies() {
+		t.Error("SecureCookies() = true, want false")
+	}
+}
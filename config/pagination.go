@@ -2,5 +2,40 @@ package config
 
 type PaginationConfig struct {
 	Page      int
-	PageLimit int
+	PageLimit int `mapstructure:"PAGE_LIMIT"`
+
+	// MaxPerPage caps the page size a client may ask for
+	MaxPerPage int `mapstructure:"MAX_PER_PAGE"`
+}
+
+// Defaults applied when PAGE_LIMIT and MAX_PER_PAGE are not set
+const (
+	DefaultPageLimit  = 10
+	DefaultMaxPerPage = 100
+)
+
+// Limit returns the page and page size to use for a list request. Zero
+// means the client did not ask; a larger page size than allowed is capped.
+func (c PaginationConfig) Limit(page, perPage int) (int, int) {
+	if page <= 0 {
+		page = c.Page
+	}
+	if page <= 0 {
+		page = 1
+	}
+
+	limit, max := c.PageLimit, c.MaxPerPage
+	if limit <= 0 {
+		limit = DefaultPageLimit
+	}
+	if max <= 0 {
+		max = DefaultMaxPerPage
+	}
+	if perPage <= 0 {
+		perPage = limit
+	}
+	if perPage > max {
+		perPage = max
+	}
+	return page, perPage
 }
@@ -0,0 +1,27 @@
+package config
+
+import "testing"
+
+func TestPaginationLimit(t *testing.T) {
+	tests := []struct {
+		name              string
+		config            PaginationConfig
+		page, perPage     int
+		wantPage, wantPer int
+	}{
+		{name: "defaults", page: 0, perPage: 0, wantPage: 1, wantPer: DefaultPageLimit},
+		{name: "configured default", config: PaginationConfig{Page: 2, PageLimit: 25}, wantPage: 2, wantPer: 25},
+		{name: "requested", config: PaginationConfig{PageLimit: 25}, page: 3, perPage: 40, wantPage: 3, wantPer: 40},
+		{name: "negative values", page: -1, perPage: -5, wantPage: 1, wantPer: DefaultPageLimit},
+		{name: "capped at the default max", perPage: DefaultMaxPerPage + 1, wantPage: 1, wantPer: DefaultMaxPerPage},
+		{name: "capped at the configured max", config: PaginationConfig{MaxPerPage: 50}, perPage: 1000, wantPage: 1, wantPer: 50},
+		{name: "default above the max", config: PaginationConfig{PageLimit: 80, MaxPerPage: 50}, wantPage: 1, wantPer: 50},
+	}
+
+	for _, tt := range tests {
+		page, perPage := tt.config.Limit(tt.page, tt.perPage)
+		if page != tt.wantPage || perPage != tt.wantPer {
+			t.Errorf("%s: Limit(%d, %d) = %d, %d, want %d, %d", tt.name, tt.page, tt.perPage, page, perPage, tt.wantPage, tt.wantPer)
+		}
+	}
+}
@@ -0,0 +1,184 @@
+package controller
+
+import (
+	"errors"
+	"net/http"
+	"reports/data/request"
+	"reports/model"
+	"reports/service"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+// OrganizationController serves regions, districts, areas and churches.
+// Each handler is bound to one level of the hierarchy by the router.
+type OrganizationController struct {
+	organizationService service.OrganizationService
+}
+
+func NewOrganizationController(organizationService service.OrganizationService) *OrganizationController {
+	return &OrganizationController{organizationService: organizationService}
+}
+
+func (controller *OrganizationController) Create(level model.OrgLevel) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		var req request.OrgUnitRequest
+		if err := ctx.ShouldBindJSON(&req); err != nil {
+			ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body", "details": err.Error()})
+			return
+		}
+
+		req.Level = level
+
+		if err := req.Validate(); err != nil {
+			ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		unit, err := controller.organizationService.Create(ctx.Request.Context(), &req)
+		if err != nil {
+			writeOrgUnitError(ctx, err, "Failed to create "+string(level))
+			return
+		}
+
+		ctx.JSON(http.StatusCreated, gin.H{string(level): unit})
+	}
+}
+
+func (controller *OrganizationController) FindById(level model.OrgLevel) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		id, err := strconv.Atoi(ctx.Param("id"))
+		if err != nil {
+			ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid " + string(level) + " ID"})
+			return
+		}
+
+		unit, err := controller.organizationService.FindById(ctx.Request.Context(), level, id)
+		if err != nil {
+			writeOrgUnitError(ctx, err, "Failed to fetch "+string(level))
+			return
+		}
+
+		ctx.JSON(http.StatusOK, gin.H{string(level): unit})
+	}
+}
+
+func (controller *OrganizationController) FindAll(level model.OrgLevel) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		query := model.SearchOrgUnitQuery{
+			Level: level,
+			Name:  ctx.Query("name"),
+		}
+
+		if err := parseIdQuery(ctx, "parent_id", &query.ParentId); err != nil {
+			ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		units, err := controller.organizationService.FindAll(ctx.Request.Context(), &query)
+		if err != nil {
+			writeOrgUnitError(ctx, err, "Failed to fetch "+string(level))
+			return
+		}
+
+		ctx.JSON(http.StatusOK, gin.H{"units": units})
+	}
+}
+
+func (controller *OrganizationController) Update(level model.OrgLevel) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		var req request.OrgUnitRequest
+		if err := ctx.ShouldBindJSON(&req); err != nil {
+			ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body", "details": err.Error()})
+			return
+		}
+
+		id, err := strconv.Atoi(ctx.Param("id"))
+		if err != nil {
+			ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid " + string(level) + " ID"})
+			return
+		}
+
+		req.Id = id
+		req.Level = level
+
+		if err := req.Validate(); err != nil {
+			ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		unit, err := controller.organizationService.Update(ctx.Request.Context(), &req)
+		if err != nil {
+			writeOrgUnitError(ctx, err, "Failed to update "+string(level))
+			return
+		}
+
+		ctx.JSON(http.StatusOK, gin.H{string(level): unit})
+	}
+}
+
+func (controller *OrganizationController) Delete(level model.OrgLevel) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		id, err := strconv.Atoi(ctx.Param("id"))
+		if err != nil {
+			ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid " + string(level) + " ID"})
+			return
+		}
+
+		if err := controller.organizationService.Delete(ctx.Request.Context(), level, id); err != nil {
+			writeOrgUnitError(ctx, err, "Failed to delete "+string(level))
+			return
+		}
+
+		ctx.JSON(http.StatusOK, gin.H{"message": "Deleted successfully"})
+	}
+}
+
+// Rollup aggregates one activity of the filtered reports by hierarchy level
+func (controller *OrganizationController) Rollup(ctx *gin.Context) {
+	filter, err := parseReportQuery(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	query := model.RollupQuery{
+		Metric: ctx.Query("metric"),
+		Level:  model.OrgLevel(ctx.DefaultQuery("level", string(model.LevelChurch))),
+		Filter: filter,
+	}
+
+	if !model.ValidRollupMetric(query.Metric) {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid metric"})
+		return
+	}
+	if !query.Level.Valid() {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid level"})
+		return
+	}
+
+	result, err := controller.organizationService.Rollup(ctx.Request.Context(), &query)
+	if err != nil {
+		writeOrgUnitError(ctx, err, "Failed to roll up reports")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"rollup": result})
+}
+
+// writeOrgUnitError maps service errors to the matching HTTP status
+func writeOrgUnitError(ctx *gin.Context, err error, message string) {
+	switch {
+	case errors.Is(err, service.ErrOrgUnitNotFound):
+		ctx.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.Is(err, service.ErrParentNotFound):
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	case errors.Is(err, service.ErrOrgUnitNameTaken), errors.Is(err, service.ErrOrgUnitInUse):
+		ctx.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	case errors.Is(err, service.ErrForbidden):
+		ctx.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+	default:
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": message, "details": err.Error()})
+	}
+}
@@ -1,19 +1,28 @@
 package controller
 
 import (
-	"context"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
 	"net/http"
 	"reports/data/request"
+	"reports/data/response"
 	"reports/model"
 	"reports/service"
 	"reports/utils"
 
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/tealeg/xlsx"
 )
 
+// maxTrendMonths bounds the range of a trend query
+const maxTrendMonths = 60
+
 type ReportController struct {
 	reportService service.ReportService
 }
@@ -25,17 +34,17 @@ func NewReportController(reportService service.ReportService) *ReportController
 func (controller *ReportController) Create(ctx *gin.Context) {
 	var req request.ReportCreateRequest
 	if err := ctx.ShouldBindJSON(&req); err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body"})
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body", "details": err.Error()})
 		return
 	}
 
 	if err := req.Validate(); err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		writeReportError(ctx, err, "Invalid report")
 		return
 	}
 
 	if err := controller.reportService.Create(ctx.Request.Context(), &req); err != nil {
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create report", "details": err.Error()})
+		writeReportError(ctx, err, "Failed to create report")
 		return
 	}
 
@@ -49,40 +58,33 @@ func (controller *ReportController) FindById(ctx *gin.Context) {
 		return
 	}
 
-	report, err := controller.reportService.FindById(ctx, reportId)
+	report, err := controller.reportService.FindById(ctx.Request.Context(), reportId)
 	if err != nil {
-		ctx.JSON(http.StatusNotFound, gin.H{"error": "Report not found", "details": err.Error()})
+		writeReportError(ctx, err, "Failed to fetch report")
 		return
 	}
 
+	setReportETag(ctx, report)
 	ctx.JSON(http.StatusOK, gin.H{"report": report})
 }
 
 func (controller *ReportController) FindAll(ctx *gin.Context) {
 	// Parse query parameters
-	query := model.SearchReportQuery{
-		MonthOf:    ctx.DefaultQuery("month_of", ""),
-		WorkerName: ctx.Query("worker_name"),
-		Page:       parsePage(ctx.DefaultQuery("page", "1")),
-		PerPage:    parsePerPage(ctx.DefaultQuery("per_page", "10")),
+	query, err := parseReportQuery(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
 	}
 
 	// Call the service layer to fetch data
-	result, err := controller.reportService.FindAll(ctx, &query)
+	result, err := controller.reportService.FindAll(ctx.Request.Context(), query)
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch reports", "details": err.Error()})
+		writeReportError(ctx, err, "Failed to fetch reports")
 		return
 	}
 
 	// Return the JSON response
-	ctx.JSON(http.StatusOK, gin.H{
-		"reports": gin.H{
-			"total_count": result.TotalCount,
-			"reports":     result.Reports,
-			"page":        query.Page,
-			"per_page":    query.PerPage,
-		},
-	})
+	ctx.JSON(http.StatusOK, gin.H{"reports": reportPage(ctx, result)})
 }
 func (controller *ReportController) Delete(ctx *gin.Context) {
 	reportId, err := strconv.Atoi(ctx.Param("reportId"))
@@ -91,19 +93,100 @@ func (controller *ReportController) Delete(ctx *gin.Context) {
 		return
 	}
 
-	if err := controller.reportService.Delete(ctx, reportId); err != nil {
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to delete report", "details": err.Error()})
+	if err := controller.reportService.Delete(ctx.Request.Context(), reportId); err != nil {
+		writeReportError(ctx, err, "Failed to delete report")
 		return
 	}
 
-	ctx.JSON(http.StatusOK, gin.H{"message": "Report deleted successfully"})
+	ctx.JSON(http.StatusOK, gin.H{"message": "Report moved to the trash"})
+}
+
+// Search ranks reports by their narrative, challenges and prayer requests.
+// It takes the search terms in q and the same filters as FindAll.
+func (controller *ReportController) Search(ctx *gin.Context) {
+	query, err := parseReportQuery(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	result, err := controller.reportService.Search(ctx.Request.Context(), query)
+	if err != nil {
+		writeReportError(ctx, err, "Failed to search reports")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, result)
+}
+
+// FindPeople lists the reports that name a person, to follow them from
+// month to month. It takes the name and the same filters as FindAll.
+func (controller *ReportController) FindPeople(ctx *gin.Context) {
+	query, err := parseReportQuery(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	people, err := controller.reportService.FindPeople(ctx.Request.Context(), ctx.Query("name"), query)
+	if err != nil {
+		writeReportError(ctx, err, "Failed to fetch people")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"people": people})
+}
+
+// Trash lists deleted reports; it takes the same filters as FindAll
+func (controller *ReportController) Trash(ctx *gin.Context) {
+	query, err := parseReportQuery(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	result, err := controller.reportService.Trash(ctx.Request.Context(), query)
+	if err != nil {
+		writeReportError(ctx, err, "Failed to fetch deleted reports")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"reports": reportPage(ctx, result)})
+}
+
+func (controller *ReportController) Restore(ctx *gin.Context) {
+	reportId, err := strconv.Atoi(ctx.Param("reportId"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid report ID"})
+		return
+	}
+
+	report, err := controller.reportService.Restore(ctx.Request.Context(), reportId)
+	if err != nil {
+		writeReportError(ctx, err, "Failed to restore report")
+		return
+	}
+
+	setReportETag(ctx, report)
+	ctx.JSON(http.StatusOK, gin.H{"report": report})
+}
+
+// Purge empties the trash of reports older than the retention period
+func (controller *ReportController) Purge(ctx *gin.Context) {
+	result, err := controller.reportService.Purge(ctx.Request.Context())
+	if err != nil {
+		writeReportError(ctx, err, "Failed to purge deleted reports")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, result)
 }
 
 func (controller *ReportController) Update(ctx *gin.Context) {
 	var req request.ReportUpdateRequest
 
 	if err := ctx.ShouldBindJSON(&req); err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body"})
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body", "details": err.Error()})
 		return
 	}
 
@@ -115,15 +198,21 @@ func (controller *ReportController) Update(ctx *gin.Context) {
 
 	req.Id = reportId
 
+	version, ok := requireIfMatch(ctx)
+	if !ok {
+		return
+	}
+	req.Version = version
+
 	// Validate request parameters
 	if err := req.Validate(); err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		writeReportError(ctx, err, "Invalid report")
 		return
 	}
 
 	// Call service layer to update the report
-	if err := controller.reportService.Update(ctx, &req); err != nil {
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update report", "details": err.Error()})
+	if err := controller.reportService.Update(ctx.Request.Context(), &req); err != nil {
+		writeReportError(ctx, err, "Failed to update report")
 		return
 	}
 
@@ -131,6 +220,158 @@ func (controller *ReportController) Update(ctx *gin.Context) {
 	ctx.JSON(http.StatusOK, gin.H{"message": "Report updated successfully"})
 }
 
+// Patch updates only the fields present in the body, following JSON Merge
+// Patch: a null clears the field and an array replaces the whole array
+func (controller *ReportController) Patch(ctx *gin.Context) {
+	reportId, err := strconv.Atoi(ctx.Param("reportId"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid report ID"})
+		return
+	}
+
+	switch ctx.ContentType() {
+	case "application/merge-patch+json", "application/json":
+	default:
+		ctx.JSON(http.StatusUnsupportedMediaType, gin.H{"error": "Content-Type must be application/merge-patch+json"})
+		return
+	}
+
+	version, ok := requireIfMatch(ctx)
+	if !ok {
+		return
+	}
+
+	var patch map[string]interface{}
+	if err := json.NewDecoder(ctx.Request.Body).Decode(&patch); err != nil || patch == nil {
+		details := "the patch must be a JSON object"
+		if err != nil {
+			details = err.Error()
+		}
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body", "details": details})
+		return
+	}
+
+	report, err := controller.reportService.Patch(ctx.Request.Context(), reportId, version, patch)
+	if err != nil {
+		writeReportError(ctx, err, "Failed to update report")
+		return
+	}
+
+	setReportETag(ctx, report)
+	ctx.JSON(http.StatusOK, gin.H{"report": report})
+}
+
+func (controller *ReportController) Submit(ctx *gin.Context) {
+	reportId, err := strconv.Atoi(ctx.Param("reportId"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid report ID"})
+		return
+	}
+
+	report, err := controller.reportService.Submit(ctx.Request.Context(), reportId)
+	if err != nil {
+		writeReportError(ctx, err, "Failed to submit report")
+		return
+	}
+
+	setReportETag(ctx, report)
+	ctx.JSON(http.StatusOK, gin.H{"report": report})
+}
+
+// History lists who changed the report, when, and what they changed
+func (controller *ReportController) History(ctx *gin.Context) {
+	reportId, err := strconv.Atoi(ctx.Param("reportId"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid report ID"})
+		return
+	}
+
+	revisions, err := controller.reportService.History(ctx.Request.Context(), reportId)
+	if err != nil {
+		writeReportError(ctx, err, "Failed to retrieve report history")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"revisions": revisions})
+}
+
+// Revision shows one past version of the report
+func (controller *ReportController) Revision(ctx *gin.Context) {
+	reportId, err := strconv.Atoi(ctx.Param("reportId"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid report ID"})
+		return
+	}
+
+	revisionId, err := strconv.Atoi(ctx.Param("revisionId"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid revision ID"})
+		return
+	}
+
+	revision, err := controller.reportService.Revision(ctx.Request.Context(), reportId, revisionId)
+	if err != nil {
+		writeReportError(ctx, err, "Failed to retrieve revision")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"revision": revision})
+}
+
+func (controller *ReportController) Approve(ctx *gin.Context) {
+	reportId, err := strconv.Atoi(ctx.Param("reportId"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid report ID"})
+		return
+	}
+
+	// The note is optional when approving
+	var req request.ReportReviewRequest
+	if ctx.Request.ContentLength != 0 {
+		if err := ctx.ShouldBindJSON(&req); err != nil {
+			ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body", "details": err.Error()})
+			return
+		}
+	}
+
+	report, err := controller.reportService.Approve(ctx.Request.Context(), reportId, &req)
+	if err != nil {
+		writeReportError(ctx, err, "Failed to approve report")
+		return
+	}
+
+	setReportETag(ctx, report)
+	ctx.JSON(http.StatusOK, gin.H{"report": report})
+}
+
+func (controller *ReportController) Return(ctx *gin.Context) {
+	reportId, err := strconv.Atoi(ctx.Param("reportId"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid report ID"})
+		return
+	}
+
+	var req request.ReportReviewRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body", "details": err.Error()})
+		return
+	}
+
+	if err := req.ValidateReturn(); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	report, err := controller.reportService.Return(ctx.Request.Context(), reportId, &req)
+	if err != nil {
+		writeReportError(ctx, err, "Failed to return report")
+		return
+	}
+
+	setReportETag(ctx, report)
+	ctx.JSON(http.StatusOK, gin.H{"report": report})
+}
+
 func (controller *ReportController) ExportReport(ctx *gin.Context) {
 	reportId, err := strconv.Atoi(ctx.Param("reportId"))
 	if err != nil {
@@ -138,9 +379,30 @@ func (controller *ReportController) ExportReport(ctx *gin.Context) {
 		return
 	}
 
-	report, err := controller.reportService.FindById(context.Background(), reportId)
+	format := ctx.DefaultQuery("format", "xlsx")
+	if format != "xlsx" && format != "csv" && format != "pdf" {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid format"})
+		return
+	}
+
+	report, err := controller.reportService.FindById(ctx.Request.Context(), reportId)
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		writeReportError(ctx, err, "Failed to fetch report")
+		return
+	}
+
+	switch format {
+	case "csv":
+		writeReportsCSV(ctx, "report.csv", []*response.ReportResponse{report})
+		return
+	case "pdf":
+		ctx.Header("Content-Description", "File Transfer")
+		ctx.Header("Content-Disposition", "attachment; filename=report.pdf")
+		ctx.Header("Content-Type", "application/pdf")
+
+		if err := utils.WriteReportPDF(ctx.Writer, report); err != nil {
+			ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to write PDF file"})
+		}
 		return
 	}
 
@@ -166,6 +428,348 @@ func (controller *ReportController) ExportReport(ctx *gin.Context) {
 	}
 }
 
+// ExportReports writes every report matching the FindAll filters into one
+// workbook: an index sheet followed by one sheet per worker
+func (controller *ReportController) ExportReports(ctx *gin.Context) {
+	query, err := parseReportQuery(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	format := ctx.DefaultQuery("format", "xlsx")
+	if format != "xlsx" && format != "csv" {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid format"})
+		return
+	}
+
+	reports, err := controller.reportService.FindAllForExport(ctx.Request.Context(), query)
+	if err != nil {
+		writeReportError(ctx, err, "Failed to fetch reports")
+		return
+	}
+
+	if len(reports) == 0 {
+		ctx.JSON(http.StatusNotFound, gin.H{"error": "No reports match the filters"})
+		return
+	}
+
+	var suffix string
+	if !query.MonthOf.IsZero() {
+		suffix = query.MonthOf.String()
+	}
+
+	if format == "csv" {
+		writeReportsCSV(ctx, utils.ExportFileName("reports", suffix, "csv"), reports)
+		return
+	}
+
+	file := xlsx.NewFile()
+	if err := utils.AddReportsToWorkbook(file, reports); err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create Excel workbook", "details": err.Error()})
+		return
+	}
+
+	ctx.Header("Content-Description", "File Transfer")
+	ctx.Header("Content-Disposition", "attachment; filename="+utils.ExportFileName("reports", suffix, "xlsx"))
+	ctx.Header("Content-Type", "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet")
+
+	if err := file.Write(ctx.Writer); err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to write Excel file"})
+	}
+}
+
+// AreaSummary consolidates every report of an area for one month into a
+// summary sheet, or returns it as JSON with format=json
+func (controller *ReportController) AreaSummary(ctx *gin.Context) {
+	var areaId int
+	var monthOf model.Period
+	if err := parseIdQuery(ctx, "area_id", &areaId); err != nil || areaId == 0 {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "area_id is required"})
+		return
+	}
+	if err := parsePeriodQuery(ctx, "month_of", &monthOf); err != nil || monthOf.IsZero() {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "month_of is required"})
+		return
+	}
+
+	summary, err := controller.reportService.AreaSummary(ctx.Request.Context(), areaId, monthOf)
+	if err != nil {
+		writeReportError(ctx, err, "Failed to summarize reports")
+		return
+	}
+
+	if ctx.Query("format") == "json" {
+		ctx.JSON(http.StatusOK, gin.H{"summary": summary})
+		return
+	}
+
+	file := xlsx.NewFile()
+	sheet, err := file.AddSheet("Summary")
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create Excel sheet"})
+		return
+	}
+
+	utils.AddAreaSummaryToSheet(sheet, summary)
+
+	ctx.Header("Content-Description", "File Transfer")
+	ctx.Header("Content-Disposition", "attachment; filename="+utils.ExportFileName("area_summary_"+strconv.Itoa(areaId), monthOf.String(), "xlsx"))
+	ctx.Header("Content-Type", "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet")
+
+	if err := file.Write(ctx.Writer); err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to write Excel file"})
+	}
+}
+
+// Trends returns the monthly average of each activity for a worker, church
+// or area. The range defaults to the twelve months ending with month_to, or
+// with the current month.
+func (controller *ReportController) Trends(ctx *gin.Context) {
+	var query model.TrendQuery
+
+	ids := []struct {
+		key string
+		dst *int
+	}{
+		{"worker_id", &query.WorkerId},
+		{"church_id", &query.ChurchId},
+		{"area_id", &query.AreaId},
+	}
+	for _, id := range ids {
+		if err := parseIdQuery(ctx, id.key, id.dst); err != nil {
+			ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+	}
+	if query.WorkerId == 0 && query.ChurchId == 0 && query.AreaId == 0 {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "worker_id, church_id or area_id is required"})
+		return
+	}
+
+	if err := parsePeriodQuery(ctx, "month_to", &query.MonthTo); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid month_to: " + err.Error()})
+		return
+	}
+	if query.MonthTo.IsZero() {
+		query.MonthTo = model.PeriodOf(time.Now())
+	}
+	if err := parsePeriodQuery(ctx, "month_from", &query.MonthFrom); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid month_from: " + err.Error()})
+		return
+	}
+	if query.MonthFrom.IsZero() {
+		query.MonthFrom = query.MonthTo.AddMonths(-11)
+	}
+
+	if months := query.MonthFrom.MonthsUntil(query.MonthTo); months < 0 || months >= maxTrendMonths {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("month_from must be before month_to and at most %d months apart", maxTrendMonths)})
+		return
+	}
+
+	// activity=worship_service,sunday_school limits the series
+	if keys := ctx.Query("activity"); keys != "" {
+		for _, key := range strings.Split(keys, ",") {
+			activity, ok := model.FindActivity(strings.TrimSpace(key))
+			if !ok {
+				ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid activity " + key})
+				return
+			}
+			query.Activities = append(query.Activities, activity)
+		}
+	}
+
+	result, err := controller.reportService.Trends(ctx.Request.Context(), &query)
+	if err != nil {
+		writeReportError(ctx, err, "Failed to compute trends")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"trends": result})
+}
+
+// AnnualReport returns a worker's report for the year as JSON
+func (controller *ReportController) AnnualReport(ctx *gin.Context) {
+	annual, ok := controller.findAnnualReport(ctx)
+	if !ok {
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"annual_report": annual})
+}
+
+// ExportAnnualReport writes a worker's report for the year to Excel
+func (controller *ReportController) ExportAnnualReport(ctx *gin.Context) {
+	annual, ok := controller.findAnnualReport(ctx)
+	if !ok {
+		return
+	}
+
+	file := xlsx.NewFile()
+	sheet, err := file.AddSheet("Annual Report")
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create Excel sheet"})
+		return
+	}
+
+	utils.AddAnnualReportToSheet(sheet, annual)
+
+	ctx.Header("Content-Description", "File Transfer")
+	ctx.Header("Content-Disposition", "attachment; filename="+utils.ExportFileName("annual_report_"+strconv.Itoa(annual.WorkerId), annual.Through.String(), "xlsx"))
+	ctx.Header("Content-Type", "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet")
+
+	if err := file.Write(ctx.Writer); err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to write Excel file"})
+	}
+}
+
+// findAnnualReport reads year (default: this year) and either through, an
+// explicit last month, or ytd=true, up to the current month. Without them
+// the report covers the full year.
+func (controller *ReportController) findAnnualReport(ctx *gin.Context) (*model.AnnualReport, bool) {
+	workerId, err := strconv.Atoi(ctx.Param("workerId"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid worker ID"})
+		return nil, false
+	}
+
+	now := time.Now()
+	year := now.Year()
+	if value := ctx.Query("year"); value != "" {
+		year, err = strconv.Atoi(value)
+		if err != nil || year < 1900 {
+			ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid year"})
+			return nil, false
+		}
+	}
+
+	through := model.NewPeriod(year, time.December)
+	if ctx.Query("ytd") == "true" {
+		through = model.AnnualThrough(year, now)
+	}
+	if err := parsePeriodQuery(ctx, "through", &through); err != nil || through.Year != year {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "through must be a month of the requested year"})
+		return nil, false
+	}
+
+	annual, err := controller.reportService.AnnualReport(ctx.Request.Context(), workerId, year, through)
+	if err != nil {
+		writeReportError(ctx, err, "Failed to build annual report")
+		return nil, false
+	}
+
+	return annual, true
+}
+
+// ImportReports creates reports from a CSV file laid out like the CSV
+// export, sent either as the multipart field "file" or as the request body
+func (controller *ReportController) ImportReports(ctx *gin.Context) {
+	body, ok := readUpload(ctx)
+	if !ok {
+		return
+	}
+
+	rows, err := utils.ReadReportsCSV(bytes.NewReader(body))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid CSV file", "details": err.Error()})
+		return
+	}
+
+	result, err := controller.reportService.Import(ctx.Request.Context(), rows)
+	if err != nil {
+		writeReportError(ctx, err, "Failed to import reports")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"import": result})
+}
+
+// ImportSheet reads a monthly report workbook laid out like the Excel
+// export, sent as the multipart field "file" or as the request body. It
+// returns a preview of the changes; apply=true also saves them. Applying
+// over an existing report takes the version of the preview in If-Match.
+func (controller *ReportController) ImportSheet(ctx *gin.Context) {
+	apply := ctx.Query("apply") == "true"
+
+	version := 0
+	if apply && ctx.GetHeader("If-Match") != "" {
+		var ok bool
+		if version, ok = requireIfMatch(ctx); !ok {
+			return
+		}
+	}
+
+	body, ok := readUpload(ctx)
+	if !ok {
+		return
+	}
+
+	workbook, err := xlsx.OpenBinary(body)
+	if err != nil || len(workbook.Sheets) == 0 {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid Excel file"})
+		return
+	}
+
+	sheet := utils.ReadReportSheet(workbook.Sheets[0])
+	sheet.Version = version
+
+	result, err := controller.reportService.ImportSheet(ctx.Request.Context(), sheet, apply)
+	if err != nil {
+		writeReportError(ctx, err, "Failed to import report")
+		return
+	}
+
+	status := http.StatusOK
+	if len(result.Errors) > 0 {
+		status = http.StatusUnprocessableEntity
+	}
+
+	ctx.JSON(status, gin.H{"import": result})
+}
+
+func writeReportsCSV(ctx *gin.Context, filename string, reports []*response.ReportResponse) {
+	ctx.Header("Content-Description", "File Transfer")
+	ctx.Header("Content-Disposition", "attachment; filename="+filename)
+	ctx.Header("Content-Type", "text/csv; charset=utf-8")
+
+	if err := utils.WriteReportsCSV(ctx.Writer, reports); err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to write CSV file"})
+	}
+}
+
+// writeReportError maps service errors to the matching HTTP status
+func writeReportError(ctx *gin.Context, err error, message string) {
+	var takenErr *service.ReportAlreadySubmittedError
+	var validationErr *model.ValidationError
+	switch {
+	case errors.As(err, &takenErr):
+		ctx.JSON(http.StatusConflict, gin.H{"error": takenErr.Error(), "report_id": takenErr.ReportId})
+	case errors.Is(err, service.ErrReportNotFound):
+		ctx.JSON(http.StatusNotFound, gin.H{"error": "Report not found"})
+	case errors.Is(err, service.ErrAreaNotFound), errors.Is(err, service.ErrRevisionNotFound):
+		ctx.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.Is(err, service.ErrInvalidTransition), errors.Is(err, service.ErrReportLocked):
+		ctx.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	case errors.Is(err, service.ErrReportModified):
+		ctx.JSON(http.StatusPreconditionFailed, gin.H{"error": err.Error()})
+	case errors.Is(err, service.ErrVersionRequired):
+		ctx.JSON(http.StatusPreconditionRequired, gin.H{"error": err.Error()})
+	case errors.Is(err, service.ErrReportIncomplete) && errors.As(err, &validationErr):
+		ctx.JSON(http.StatusUnprocessableEntity, gin.H{"error": service.ErrReportIncomplete.Error(), "fields": validationErr.Fields})
+	case errors.Is(err, service.ErrReportIncomplete):
+		ctx.JSON(http.StatusUnprocessableEntity, gin.H{"error": err.Error()})
+	case errors.As(err, &validationErr):
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid report", "fields": validationErr.Fields})
+	case errors.Is(err, service.ErrWorkerNotFound), errors.Is(err, service.ErrChurchNotFound), errors.Is(err, service.ErrInvalidPatch),
+		errors.Is(err, service.ErrPersonNameMissing), errors.Is(err, service.ErrSearchTermMissing):
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	case errors.Is(err, service.ErrForbidden):
+		ctx.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+	default:
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": message, "details": err.Error()})
+	}
+}
+
 func parsePage(pageStr string) int {
 	page, err := strconv.Atoi(pageStr)
 	if err != nil || page < 1 {
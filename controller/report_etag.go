@@ -0,0 +1,49 @@
+package controller
+
+import (
+	"net/http"
+	"reports/data/request"
+	"reports/data/response"
+	"reports/service"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// reportETag is the strong entity tag of one version of a report
+func reportETag(version int) string {
+	return `"` + strconv.Itoa(version) + `"`
+}
+
+func setReportETag(ctx *gin.Context, report *response.ReportResponse) {
+	ctx.Header("ETag", reportETag(report.Version))
+}
+
+// requireIfMatch reads the version the client last saw from If-Match. "*"
+// accepts any version and returns request.AnyVersion. When the header is
+// missing or unusable it writes the response and returns false.
+func requireIfMatch(ctx *gin.Context) (int, bool) {
+	header := strings.TrimSpace(ctx.GetHeader("If-Match"))
+	if header == "" {
+		ctx.JSON(http.StatusPreconditionRequired, gin.H{"error": "If-Match is required; send the ETag returned by GET"})
+		return 0, false
+	}
+	if header == "*" {
+		return request.AnyVersion, true
+	}
+
+	// Weak tags never match under the strong comparison If-Match uses
+	if strings.HasPrefix(header, "W/") {
+		ctx.JSON(http.StatusPreconditionFailed, gin.H{"error": service.ErrReportModified.Error()})
+		return 0, false
+	}
+
+	version, err := strconv.Atoi(strings.Trim(header, `"`))
+	if err != nil || version <= 0 || !strings.HasPrefix(header, `"`) || !strings.HasSuffix(header, `"`) {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "If-Match must be a single ETag returned by GET, or *"})
+		return 0, false
+	}
+
+	return version, true
+}
@@ -0,0 +1,46 @@
+package controller
+
+import (
+	"reports/model"
+
+	"github.com/gin-gonic/gin"
+)
+
+// reportPage lays out a page of reports with the links to the pages around
+// it. The links repeat the request with its cursor replaced, so the filters
+// and sort carry over.
+func reportPage(ctx *gin.Context, result *model.SearchReportResult) gin.H {
+	page := gin.H{
+		"reports":  result.Reports,
+		"per_page": result.PerPage,
+	}
+	if result.TotalCount != nil {
+		page["total_count"] = *result.TotalCount
+	}
+	if result.Page > 0 {
+		page["page"] = result.Page
+	}
+
+	links := gin.H{}
+	if result.NextCursor != "" {
+		page["next_cursor"] = result.NextCursor
+		links["next"] = cursorLink(ctx, result.NextCursor)
+	}
+	if result.PrevCursor != "" {
+		page["prev_cursor"] = result.PrevCursor
+		links["prev"] = cursorLink(ctx, result.PrevCursor)
+	}
+	page["links"] = links
+
+	return page
+}
+
+// cursorLink is the request URI with the page replaced by the cursor
+func cursorLink(ctx *gin.Context, cursor string) string {
+	link := *ctx.Request.URL
+	values := link.Query()
+	values.Del("page")
+	values.Set("cursor", cursor)
+	link.RawQuery = values.Encode()
+	return link.RequestURI()
+}
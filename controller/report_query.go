@@ -0,0 +1,201 @@
+package controller
+
+import (
+	"fmt"
+	"math"
+	"reports/model"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// parseReportQuery reads the report list filters shared by listing, exports
+// and statistics from the query string
+func parseReportQuery(ctx *gin.Context) (*model.SearchReportQuery, error) {
+	query := &model.SearchReportQuery{
+		WorkerName: ctx.Query("worker_name"),
+		Q:          strings.TrimSpace(ctx.Query("q")),
+		Page:       parsePage(ctx.DefaultQuery("page", "1")),
+
+		AreaOfAssignment: strings.TrimSpace(ctx.Query("area_of_assignment")),
+		NameOfChurch:     strings.TrimSpace(ctx.Query("name_of_church")),
+	}
+
+	periods := []struct {
+		key string
+		dst *model.Period
+	}{
+		{"month_of", &query.MonthOf},
+		{"month_from", &query.MonthFrom},
+		{"month_to", &query.MonthTo},
+	}
+	for _, p := range periods {
+		if err := parsePeriodQuery(ctx, p.key, p.dst); err != nil {
+			return nil, fmt.Errorf("invalid %s: %w", p.key, err)
+		}
+	}
+
+	ids := []struct {
+		key string
+		dst *int
+	}{
+		{"worker_id", &query.WorkerId},
+		{"church_id", &query.ChurchId},
+		{"area_id", &query.AreaId},
+		{"district_id", &query.DistrictId},
+		{"region_id", &query.RegionId},
+	}
+	for _, id := range ids {
+		if err := parseIdQuery(ctx, id.key, id.dst); err != nil {
+			return nil, err
+		}
+	}
+
+	if status := ctx.Query("status"); status != "" {
+		query.Status = model.ReportStatus(status)
+		if !query.Status.Valid() {
+			return nil, fmt.Errorf("invalid status")
+		}
+	}
+
+	times := []struct {
+		key   string
+		dst   *time.Time
+		until bool
+	}{
+		{"created_from", &query.CreatedFrom, false},
+		{"created_to", &query.CreatedTo, true},
+		{"updated_from", &query.UpdatedFrom, false},
+		{"updated_to", &query.UpdatedTo, true},
+	}
+	for _, t := range times {
+		if err := parseTimeQuery(ctx, t.key, t.dst, t.until); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, activity := range model.Activities {
+		for _, bound := range []struct {
+			suffix string
+			dst    *map[string]float64
+		}{
+			{"_average_min", &query.AverageMin},
+			{"_average_max", &query.AverageMax},
+		} {
+			key := activity.Key + bound.suffix
+			value := ctx.Query(key)
+			if value == "" {
+				continue
+			}
+			n, err := strconv.ParseFloat(value, 64)
+			if err != nil || n < 0 || math.IsNaN(n) || math.IsInf(n, 0) {
+				return nil, fmt.Errorf("invalid %s", key)
+			}
+			if *bound.dst == nil {
+				*bound.dst = map[string]float64{}
+			}
+			(*bound.dst)[activity.Key] = n
+		}
+	}
+
+	sort, err := model.ParseReportSort(ctx.Query("sort"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid sort: %w", err)
+	}
+	query.Sort = sort
+
+	// Without per_page the service applies the configured page size
+	if perPage := ctx.Query("per_page"); perPage != "" {
+		query.PerPage = parsePerPage(perPage)
+	}
+
+	if value := ctx.Query("cursor"); value != "" {
+		cursor, err := model.ParseReportCursor(value)
+		if err != nil {
+			return nil, err
+		}
+		if ctx.Query("sort") != "" && model.FormatReportSort(query.Sort) != cursor.Sort {
+			return nil, fmt.Errorf("invalid cursor: it was made for sort=%s", cursor.Sort)
+		}
+		query.Cursor = cursor
+		query.Sort = cursor.Fields
+	}
+
+	if total := ctx.Query("total_count"); total != "" {
+		withTotal, err := strconv.ParseBool(total)
+		if err != nil {
+			return nil, fmt.Errorf("invalid total_count")
+		}
+		query.SkipTotal = !withTotal
+	}
+
+	if year := ctx.Query("year"); year != "" {
+		parsedYear, err := strconv.Atoi(year)
+		if err != nil || parsedYear < 1900 {
+			return nil, fmt.Errorf("invalid year")
+		}
+		query.Year = parsedYear
+	}
+
+	return query, nil
+}
+
+// parsePeriodQuery parses an optional month query parameter into dst
+func parsePeriodQuery(ctx *gin.Context, key string, dst *model.Period) error {
+	value := ctx.Query(key)
+	if value == "" {
+		return nil
+	}
+
+	period, err := model.ParsePeriod(value)
+	if err != nil {
+		return err
+	}
+
+	*dst = period
+	return nil
+}
+
+// parseTimeQuery parses an optional RFC 3339 time or YYYY-MM-DD date query
+// parameter into dst. A date is midnight UTC, or with until the last moment
+// of that day, so that a range of dates includes its last day.
+func parseTimeQuery(ctx *gin.Context, key string, dst *time.Time, until bool) error {
+	value := ctx.Query(key)
+	if value == "" {
+		return nil
+	}
+
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		*dst = t
+		return nil
+	}
+
+	day, err := time.Parse("2006-01-02", value)
+	if err != nil {
+		return fmt.Errorf("invalid %s: use YYYY-MM-DD or an RFC 3339 time", key)
+	}
+	if until {
+		day = day.AddDate(0, 0, 1).Add(-time.Microsecond)
+	}
+
+	*dst = day
+	return nil
+}
+
+// parseIdQuery parses an optional positive id query parameter into dst
+func parseIdQuery(ctx *gin.Context, key string, dst *int) error {
+	value := ctx.Query(key)
+	if value == "" {
+		return nil
+	}
+
+	id, err := strconv.Atoi(value)
+	if err != nil || id < 1 {
+		return fmt.Errorf("invalid %s", key)
+	}
+
+	*dst = id
+	return nil
+}
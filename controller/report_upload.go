@@ -0,0 +1,58 @@
+package controller
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// maxUploadSize bounds an imported file, whether sent as the request body
+// or as a multipart upload; a filled-in report is a few hundred kilobytes
+const maxUploadSize = 10 << 20
+
+// maxUploadMemory is how much of a multipart upload is kept in memory; the
+// rest goes to a temporary file
+const maxUploadMemory = 2 << 20
+
+// readUpload reads an imported file, sent as the multipart field "file" or
+// as the request body. When the file is too large or cannot be read it
+// writes the response and returns false.
+func readUpload(ctx *gin.Context) ([]byte, bool) {
+	ctx.Request.Body = http.MaxBytesReader(ctx.Writer, ctx.Request.Body, maxUploadSize)
+
+	var reader io.Reader = ctx.Request.Body
+	if strings.HasPrefix(ctx.ContentType(), "multipart/") {
+		if err := ctx.Request.ParseMultipartForm(maxUploadMemory); err != nil {
+			writeUploadError(ctx, err)
+			return nil, false
+		}
+
+		file, _, err := ctx.Request.FormFile("file")
+		if err != nil {
+			ctx.JSON(http.StatusBadRequest, gin.H{"error": "Missing file", "details": err.Error()})
+			return nil, false
+		}
+		defer file.Close()
+		reader = file
+	}
+
+	body, err := io.ReadAll(reader)
+	if err != nil {
+		writeUploadError(ctx, err)
+		return nil, false
+	}
+
+	return body, true
+}
+
+func writeUploadError(ctx *gin.Context, err error) {
+	var tooLarge *http.MaxBytesError
+	if errors.As(err, &tooLarge) {
+		ctx.JSON(http.StatusRequestEntityTooLarge, gin.H{"error": "The file must not be larger than 10 MB"})
+		return
+	}
+	ctx.JSON(http.StatusBadRequest, gin.H{"error": "Failed to read file", "details": err.Error()})
+}
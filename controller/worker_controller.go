@@ -0,0 +1,177 @@
+package controller
+
+import (
+	"errors"
+	"net/http"
+	"reports/data/request"
+	"reports/model"
+	"reports/service"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type WorkerController struct {
+	workerService service.WorkerService
+}
+
+func NewWorkerController(workerService service.WorkerService) *WorkerController {
+	return &WorkerController{workerService: workerService}
+}
+
+func (controller *WorkerController) Create(ctx *gin.Context) {
+	var req request.WorkerCreateRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body", "details": err.Error()})
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	worker, err := controller.workerService.Create(ctx.Request.Context(), &req)
+	if err != nil {
+		writeWorkerError(ctx, err, "Failed to create worker")
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, gin.H{"worker": worker})
+}
+
+func (controller *WorkerController) FindById(ctx *gin.Context) {
+	workerId, err := strconv.Atoi(ctx.Param("workerId"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid worker ID"})
+		return
+	}
+
+	worker, err := controller.workerService.FindById(ctx.Request.Context(), workerId)
+	if err != nil {
+		writeWorkerError(ctx, err, "Failed to fetch worker")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"worker": worker})
+}
+
+func (controller *WorkerController) FindAll(ctx *gin.Context) {
+	query := model.SearchWorkerQuery{
+		Name: ctx.Query("name"),
+		Page: parsePage(ctx.DefaultQuery("page", "1")),
+	}
+
+	// Without per_page the service applies the configured page size
+	if perPage := ctx.Query("per_page"); perPage != "" {
+		query.PerPage = parsePerPage(perPage)
+	}
+
+	result, err := controller.workerService.FindAll(ctx.Request.Context(), &query)
+	if err != nil {
+		writeWorkerError(ctx, err, "Failed to fetch workers")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"workers": result})
+}
+
+func (controller *WorkerController) Update(ctx *gin.Context) {
+	var req request.WorkerUpdateRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body", "details": err.Error()})
+		return
+	}
+
+	workerId, err := strconv.Atoi(ctx.Param("workerId"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid worker ID"})
+		return
+	}
+
+	req.Id = workerId
+
+	if err := req.Validate(); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	worker, err := controller.workerService.Update(ctx.Request.Context(), &req)
+	if err != nil {
+		writeWorkerError(ctx, err, "Failed to update worker")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"worker": worker})
+}
+
+func (controller *WorkerController) Delete(ctx *gin.Context) {
+	workerId, err := strconv.Atoi(ctx.Param("workerId"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid worker ID"})
+		return
+	}
+
+	if err := controller.workerService.Delete(ctx.Request.Context(), workerId); err != nil {
+		writeWorkerError(ctx, err, "Failed to delete worker")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"message": "Worker deleted successfully"})
+}
+
+func (controller *WorkerController) FindDuplicates(ctx *gin.Context) {
+	duplicates, err := controller.workerService.FindDuplicates(ctx.Request.Context())
+	if err != nil {
+		writeWorkerError(ctx, err, "Failed to find duplicate workers")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"duplicates": duplicates})
+}
+
+// Merge folds the workers listed in source_ids into the worker in the path
+func (controller *WorkerController) Merge(ctx *gin.Context) {
+	var req request.WorkerMergeRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body", "details": err.Error()})
+		return
+	}
+
+	workerId, err := strconv.Atoi(ctx.Param("workerId"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid worker ID"})
+		return
+	}
+
+	req.TargetId = workerId
+
+	if err := req.Validate(); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	worker, err := controller.workerService.Merge(ctx.Request.Context(), &req)
+	if err != nil {
+		writeWorkerError(ctx, err, "Failed to merge workers")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"worker": worker})
+}
+
+// writeWorkerError maps service errors to the matching HTTP status
+func writeWorkerError(ctx *gin.Context, err error, message string) {
+	switch {
+	case errors.Is(err, service.ErrWorkerNotFound):
+		ctx.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.Is(err, service.ErrWorkerMergeSelf):
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	case errors.Is(err, service.ErrWorkerNameTaken),
+		errors.Is(err, service.ErrWorkerInUse),
+		errors.Is(err, service.ErrWorkerMergeConflict):
+		ctx.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	default:
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": message, "details": err.Error()})
+	}
+}
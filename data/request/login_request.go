@@ -0,0 +1,20 @@
+package request
+
+import "errors"
+
+type LoginRequest struct {
+	Email    string `json:"email" validate:"required"`
+	Password string `json:"password" validate:"required"`
+}
+
+func (request *LoginRequest) Validate() error {
+	if len(request.Email) == 0 {
+		return errors.New("email must not be empty")
+	}
+
+	if len(request.Password) == 0 {
+		return errors.New("password must not be empty")
+	}
+
+	return nil
+}
@@ -0,0 +1,31 @@
+package request
+
+import (
+	"errors"
+	"reports/model"
+	"strings"
+)
+
+type OrgUnitRequest struct {
+	Id       int            `json:"-"`
+	Level    model.OrgLevel `json:"-"`
+	ParentId int            `json:"parent_id"`
+	Name     string         `json:"name" validate:"required"`
+}
+
+func (request *OrgUnitRequest) Validate() error {
+	if !request.Level.Valid() {
+		return errors.New("level is invalid")
+	}
+
+	request.Name = strings.TrimSpace(request.Name)
+	if len(request.Name) == 0 {
+		return errors.New("name must not be empty")
+	}
+
+	if request.Level.Parent() != "" && request.ParentId <= 0 {
+		return errors.New("parent id must not be empty for a " + string(request.Level))
+	}
+
+	return nil
+}
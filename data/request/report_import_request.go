@@ -0,0 +1,10 @@
+package request
+
+// ReportImportRow is one row of an imported file. Errors holds the problems
+// found while reading the row; a row with errors is not created.
+type ReportImportRow struct {
+	Row        int
+	WorkerName string
+	Report     *ReportCreateRequest
+	Errors     []string
+}
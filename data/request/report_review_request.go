@@ -0,0 +1,20 @@
+package request
+
+import (
+	"errors"
+	"strings"
+)
+
+// ReportReviewRequest carries the reviewer's note when approving or
+// returning a report
+type ReportReviewRequest struct {
+	Note string `json:"note"`
+}
+
+// ValidateReturn requires a note: the worker needs to know what to revise
+func (request *ReportReviewRequest) ValidateReturn() error {
+	if strings.TrimSpace(request.Note) == "" {
+		return errors.New("note must not be empty")
+	}
+	return nil
+}
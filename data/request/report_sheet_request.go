@@ -0,0 +1,23 @@
+package request
+
+import "reports/model"
+
+// ReportSheetImport is a monthly report read back from a workbook laid out
+// like utils.AddReportToSheet. The worker, area and church are given by
+// name and resolved by the service.
+type ReportSheetImport struct {
+	WorkerName string
+	AreaName   string
+	ChurchName string
+	Report     *ReportCreateRequest
+	// Version is the version of the existing report the preview showed,
+	// from If-Match; applying the sheet over a report requires it
+	Version int
+	// Cells maps a field to the cell it was read from
+	Cells  map[string]string
+	Errors []*model.CellError
+}
+
+func (s *ReportSheetImport) AddError(field, message string) {
+	s.Errors = append(s.Errors, &model.CellError{Cell: s.Cells[field], Field: field, Message: message})
+}
@@ -1,59 +1,108 @@
 package request
 
-import "errors"
+import "reports/model"
 
 type ReportUpdateRequest struct {
-	Id                              int      `json:"id" validate:"required"`
-	MonthOf                         string   `json:"month_of" validate:"required"`
-	WorkerName                      string   `json:"worker_name" validate:"required"`
-	AreaOfAssignment                string   `json:"area_of_assignment" validate:"required"`
-	NameOfChurch                    string   `json:"name_of_church" validate:"required"`
-	WorshipService                  []int    `json:"worship_service" validate:"required"`
-	SundaySchool                    []int    `json:"sunday_school" validate:"required"`
-	PrayerMeetings                  []int    `json:"prayer_meetings,omitempty"`
-	BibleStudies                    []int    `json:"bible_studies,omitempty"`
-	MensFellowships                 []int    `json:"mens_fellowships,omitempty"`
-	WomensFellowships               []int    `json:"womens_fellowships,omitempty"`
-	YouthFellowships                []int    `json:"youth_fellowships,omitempty"`
-	ChildFellowships                []int    `json:"child_fellowships,omitempty"`
-	Outreach                        []int    `json:"outreach,omitempty"`
-	TrainingOrSeminars              []int    `json:"training_or_seminars,omitempty"`
-	LeadershipConferences           []int    `json:"leadership_conferences,omitempty"`
-	LeadershipTraining              []int    `json:"leadership_training,omitempty"`
-	Others                          []int    `json:"others,omitempty"`
-	FamilyDays                      []int    `json:"family_days,omitempty"`
-	TithesAndOfferings              []int    `json:"tithes_and_offerings,omitempty"`
-	HomeVisited                     []int    `json:"home_visited,omitempty"`
-	BibleStudyOrGroupLed            []int    `json:"bible_study_or_group_led,omitempty"`
-	SermonOrMessagePreached         []int    `json:"sermon_or_message_preached,omitempty"`
-	PersonNewlyContacted            []int    `json:"person_newly_contacted,omitempty"`
-	PersonFollowedUp                []int    `json:"person_followed_up,omitempty"`
-	PersonLedToChrist               []int    `json:"person_led_to_christ,omitempty"`
-	Names                           []string `json:"names,omitempty"`
-	NarrativeReport                 string   `json:"narrative_report" validate:"required"`
-	ChallengesAndProblemEncountered string   `json:"challenges_and_problem_encountered" validate:"required"`
-	PrayerRequest                   string   `json:"prayer_request" validate:"required"`
+	Id                              int                   `json:"id" validate:"required"`
+	MonthOf                         model.Period          `json:"month_of" validate:"required"`
+	WorkerId                        int                   `json:"worker_id" validate:"required"`
+	ChurchId                        int                   `json:"church_id" validate:"required"`
+	WorshipService                  []int                 `json:"worship_service" validate:"required"`
+	SundaySchool                    []int                 `json:"sunday_school" validate:"required"`
+	PrayerMeetings                  []int                 `json:"prayer_meetings,omitempty"`
+	BibleStudies                    []int                 `json:"bible_studies,omitempty"`
+	MensFellowships                 []int                 `json:"mens_fellowships,omitempty"`
+	WomensFellowships               []int                 `json:"womens_fellowships,omitempty"`
+	YouthFellowships                []int                 `json:"youth_fellowships,omitempty"`
+	ChildFellowships                []int                 `json:"child_fellowships,omitempty"`
+	Outreach                        []int                 `json:"outreach,omitempty"`
+	TrainingOrSeminars              []int                 `json:"training_or_seminars,omitempty"`
+	LeadershipConferences           []int                 `json:"leadership_conferences,omitempty"`
+	LeadershipTraining              []int                 `json:"leadership_training,omitempty"`
+	Others                          []int                 `json:"others,omitempty"`
+	FamilyDays                      []int                 `json:"family_days,omitempty"`
+	TithesAndOfferings              []model.Money         `json:"tithes_and_offerings,omitempty"`
+	Currency                        string                `json:"currency,omitempty"`
+	HomeVisited                     []int                 `json:"home_visited,omitempty"`
+	BibleStudyOrGroupLed            []int                 `json:"bible_study_or_group_led,omitempty"`
+	SermonOrMessagePreached         []int                 `json:"sermon_or_message_preached,omitempty"`
+	PersonNewlyContacted            []int                 `json:"person_newly_contacted,omitempty"`
+	PersonFollowedUp                []int                 `json:"person_followed_up,omitempty"`
+	PersonLedToChrist               []int                 `json:"person_led_to_christ,omitempty"`
+	People                          []*model.ReportPerson `json:"people,omitempty"`
+	NarrativeReport                 string                `json:"narrative_report" validate:"required"`
+	ChallengesAndProblemEncountered string                `json:"challenges_and_problem_encountered" validate:"required"`
+	PrayerRequest                   string                `json:"prayer_request" validate:"required"`
+
+	// Version is the version the client read, from If-Match, or AnyVersion.
+	// Reports start at version 1, so an update that leaves it 0 always fails.
+	Version int `json:"-"`
+}
+
+// AnyVersion updates whatever the current version is, as "If-Match: *" asks
+const AnyVersion = -1
+
+// MatchesVersion reports whether the update was made over the given version
+// of the report
+func (request *ReportUpdateRequest) MatchesVersion(version int) bool {
+	return request.Version == AnyVersion || request.Version == version
 }
 
 func (request *ReportUpdateRequest) Validate() error {
+	v := &model.ValidationError{}
+
 	if request.Id <= 0 {
-		return errors.New("id is invalid")
-	}
-	if len(request.MonthOf) == 0 {
-		return errors.New("month must not be empty")
+		v.Add("id", model.CodeInvalid, "id is invalid")
 	}
 
-	if len(request.WorkerName) == 0 {
-		return errors.New("worker must not be empty")
+	if request.MonthOf.IsZero() {
+		v.Add("month_of", model.CodeRequired, "month must not be empty")
 	}
 
-	if len(request.AreaOfAssignment) == 0 {
-		return errors.New("area of assignment must not be empty")
+	if request.WorkerId <= 0 {
+		v.Add("worker_id", model.CodeRequired, "worker must not be empty")
 	}
 
-	if len(request.NameOfChurch) == 0 {
-		return errors.New("name of church must not be empty")
+	if request.ChurchId <= 0 {
+		v.Add("church_id", model.CodeRequired, "church must not be empty")
 	}
 
-	return nil
+	request.report().ValidateFields(v)
+
+	return v.Err()
+}
+
+// report copies the fields that are validated like a saved report
+func (request *ReportUpdateRequest) report() *model.Report {
+	return &model.Report{
+		MonthOf:                         request.MonthOf,
+		WorkerId:                        request.WorkerId,
+		ChurchId:                        request.ChurchId,
+		WorshipService:                  request.WorshipService,
+		SundaySchool:                    request.SundaySchool,
+		PrayerMeetings:                  request.PrayerMeetings,
+		BibleStudies:                    request.BibleStudies,
+		MensFellowships:                 request.MensFellowships,
+		WomensFellowships:               request.WomensFellowships,
+		YouthFellowships:                request.YouthFellowships,
+		ChildFellowships:                request.ChildFellowships,
+		Outreach:                        request.Outreach,
+		TrainingOrSeminars:              request.TrainingOrSeminars,
+		LeadershipConferences:           request.LeadershipConferences,
+		LeadershipTraining:              request.LeadershipTraining,
+		Others:                          request.Others,
+		FamilyDays:                      request.FamilyDays,
+		TithesAndOfferings:              request.TithesAndOfferings,
+		Currency:                        request.Currency,
+		HomeVisited:                     request.HomeVisited,
+		BibleStudyOrGroupLed:            request.BibleStudyOrGroupLed,
+		SermonOrMessagePreached:         request.SermonOrMessagePreached,
+		PersonNewlyContacted:            request.PersonNewlyContacted,
+		PersonFollowedUp:                request.PersonFollowedUp,
+		PersonLedToChrist:               request.PersonLedToChrist,
+		People:                          request.People,
+		NarrativeReport:                 request.NarrativeReport,
+		ChallengesAndProblemEncountered: request.ChallengesAndProblemEncountered,
+		PrayerRequest:                   request.PrayerRequest,
+	}
 }
@@ -0,0 +1,48 @@
+package request
+
+import (
+	"errors"
+	"reports/model"
+	"strings"
+)
+
+type UserCreateRequest struct {
+	Name     string     `json:"name" validate:"required"`
+	Email    string     `json:"email" validate:"required"`
+	Password string     `json:"password" validate:"required"`
+	Role     model.Role `json:"role"`
+	WorkerId int        `json:"worker_id"`
+	AreaIds  []int      `json:"area_ids"`
+}
+
+func (request *UserCreateRequest) Validate() error {
+	if len(request.Name) == 0 {
+		return errors.New("name must not be empty")
+	}
+
+	if !strings.Contains(request.Email, "@") {
+		return errors.New("email is invalid")
+	}
+
+	if len(request.Password) < 8 {
+		return errors.New("password must be at least 8 characters")
+	}
+
+	if request.Role == "" {
+		request.Role = model.RoleWorker
+	}
+
+	if !request.Role.Valid() {
+		return errors.New("role must be one of admin, supervisor or worker")
+	}
+
+	if request.Role == model.RoleWorker && request.WorkerId <= 0 {
+		return errors.New("worker id must not be empty for a worker")
+	}
+
+	if request.Role == model.RoleSupervisor && len(request.AreaIds) == 0 {
+		return errors.New("area ids must not be empty for a supervisor")
+	}
+
+	return nil
+}
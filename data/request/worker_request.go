@@ -0,0 +1,63 @@
+package request
+
+import (
+	"errors"
+	"strings"
+)
+
+type WorkerCreateRequest struct {
+	Name string `json:"name" validate:"required"`
+}
+
+func (request *WorkerCreateRequest) Validate() error {
+	request.Name = strings.TrimSpace(request.Name)
+	if len(request.Name) == 0 {
+		return errors.New("name must not be empty")
+	}
+
+	return nil
+}
+
+type WorkerUpdateRequest struct {
+	Id   int    `json:"id" validate:"required"`
+	Name string `json:"name" validate:"required"`
+}
+
+func (request *WorkerUpdateRequest) Validate() error {
+	if request.Id <= 0 {
+		return errors.New("id is invalid")
+	}
+
+	request.Name = strings.TrimSpace(request.Name)
+	if len(request.Name) == 0 {
+		return errors.New("name must not be empty")
+	}
+
+	return nil
+}
+
+type WorkerMergeRequest struct {
+	TargetId  int   `json:"target_id"`
+	SourceIds []int `json:"source_ids" validate:"required"`
+}
+
+func (request *WorkerMergeRequest) Validate() error {
+	if request.TargetId <= 0 {
+		return errors.New("target id is invalid")
+	}
+
+	if len(request.SourceIds) == 0 {
+		return errors.New("source ids must not be empty")
+	}
+
+	for _, id := range request.SourceIds {
+		if id <= 0 {
+			return errors.New("source ids must be positive")
+		}
+		if id == request.TargetId {
+			return errors.New("a worker cannot be merged into itself")
+		}
+	}
+
+	return nil
+}
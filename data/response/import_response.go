@@ -0,0 +1,27 @@
+package response
+
+import "reports/model"
+
+// ImportRowError lists why one row of an imported file was rejected
+type ImportRowError struct {
+	Row    int      `json:"row"`
+	Errors []string `json:"errors"`
+}
+
+type ImportResponse struct {
+	Rows    int               `json:"rows"`
+	Created int               `json:"created"`
+	Errors  []*ImportRowError `json:"errors"`
+}
+
+// ReportSheetImportResponse previews, or reports, the import of one monthly
+// report workbook. Action is "create" or "update"; Applied is false for a
+// preview or when errors prevented the import.
+type ReportSheetImportResponse struct {
+	Action   string               `json:"action,omitempty"`
+	ReportId int                  `json:"report_id,omitempty"`
+	Version  int                  `json:"version,omitempty"`
+	Applied  bool                 `json:"applied"`
+	Changes  []*model.FieldChange `json:"changes"`
+	Errors   []*model.CellError   `json:"errors"`
+}
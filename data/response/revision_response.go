@@ -0,0 +1,19 @@
+package response
+
+import (
+	"reports/model"
+	"time"
+)
+
+// ReportRevisionResponse is a revision with the report as it stood after it
+type ReportRevisionResponse struct {
+	*model.ReportRevision
+	Report *ReportResponse `json:"report"`
+}
+
+// PurgeResponse lists the reports removed from the trash
+type PurgeResponse struct {
+	Purged        int       `json:"purged"`
+	ReportIds     []int     `json:"report_ids"`
+	DeletedBefore time.Time `json:"deleted_before"`
+}
@@ -0,0 +1,23 @@
+package response
+
+import (
+	"reports/model"
+	"time"
+)
+
+type UserResponse struct {
+	Id        int        `json:"id"`
+	Name      string     `json:"name"`
+	Email     string     `json:"email"`
+	Role      model.Role `json:"role"`
+	WorkerId  int        `json:"worker_id,omitempty"`
+	AreaIds   []int      `json:"area_ids,omitempty"`
+	CreatedAt time.Time  `json:"created_at"`
+	UpdatedAt time.Time  `json:"updated_at"`
+}
+
+type LoginResponse struct {
+	Token     string        `json:"token"`
+	ExpiresAt time.Time     `json:"expires_at"`
+	User      *UserResponse `json:"user"`
+}
@@ -2,6 +2,7 @@ package helper
 
 import (
 	"database/sql"
+	"errors"
 	"fmt"
 )
 
@@ -16,8 +17,9 @@ func CommitOrRollback(tx *sql.Tx) {
 			// Re-panic the original error
 			panic(err)
 		} else {
-			// No panic occurred, commit the transaction
-			if commitErr := tx.Commit(); commitErr != nil {
+			// No panic occurred, commit the transaction. The caller may have
+			// rolled it back already, leaving nothing to do.
+			if commitErr := tx.Commit(); commitErr != nil && !errors.Is(commitErr, sql.ErrTxDone) {
 				// Failed to commit, attempt to rollback
 				if rollbackErr := tx.Rollback(); rollbackErr != nil {
 					// Failed to rollback after commit failure, log both errors
@@ -1,10 +1,15 @@
 package main
 
 import (
+	"context"
 	"log"
 	"net/http"
+	"os"
 	"reports/config"
 	"reports/controller"
+	"reports/data/request"
+	"reports/migration"
+	"reports/model"
 	"reports/repository"
 	"reports/router"
 	"reports/service"
@@ -20,16 +25,80 @@ func main() {
 	// Database
 	db := config.ConnectionDB(&loadConfig)
 
+	migrator, err := migration.New(db)
+	if err != nil {
+		log.Fatal("cannot load migrations: ", err)
+	}
+
+	// "reports migrate ..." manages the schema instead of serving
+	if len(os.Args) > 1 && os.Args[1] == "migrate" {
+		if err := migration.Run(context.Background(), migrator, os.Args[2:], os.Stdout); err != nil {
+			log.Fatal("migrate: ", err)
+		}
+		return
+	}
+
+	// Refuse to serve with a known token secret or admin login, before
+	// touching the schema
+	if err := loadConfig.Validate(); err != nil {
+		log.Fatal("cannot start server: ", err)
+	}
+
+	if loadConfig.MigrateOnStart {
+		// A database set up by hand already has some of the migrations
+		baseline, err := migrator.Baseline(context.Background())
+		if err != nil {
+			log.Fatal("cannot migrate the database: ", err)
+		}
+		if baseline > 0 {
+			log.Printf("recorded migrations up to %04d as already applied", baseline)
+		}
+
+		applied, err := migrator.Up(context.Background())
+		if err != nil {
+			log.Fatal("cannot migrate the database: ", err)
+		}
+		for _, m := range applied {
+			log.Printf("applied migration %04d %s", m.Version, m.Name)
+		}
+	}
+
+	// Refuse to serve against a schema this build was not written for
+	if err := migrator.Check(context.Background()); err != nil {
+		log.Fatal("cannot start server: ", err)
+	}
+
 	// Repository
+	userRepository := repository.NewUserRepository(db)
+	workerRepository := repository.NewWorkerRepository(db)
+	organizationRepository := repository.NewOrganizationRepository(db)
 	reportRepository := repository.NewReportRepository(db)
+	revisionRepository := repository.NewRevisionRepository(db)
 
 	// Service
-	reportService := service.NewReportServiceImpl(reportRepository)
+	authService := service.NewAuthServiceImpl(userRepository, &loadConfig)
+	workerService := service.NewWorkerServiceImpl(workerRepository, loadConfig.Pagination)
+	organizationService := service.NewOrganizationServiceImpl(organizationRepository)
+	reportService := service.NewReportServiceImpl(reportRepository, workerRepository, organizationRepository, revisionRepository, &loadConfig)
+
+	// Seed the first account so there is someone who can log in
+	err = authService.EnsureUser(context.Background(), &request.UserCreateRequest{
+		Name:     loadConfig.AdminName,
+		Email:    loadConfig.AdminEmail,
+		Password: loadConfig.AdminPassword,
+		Role:     model.RoleAdmin,
+	})
+	if err != nil {
+		log.Fatal("cannot seed admin user: ", err)
+	}
 
 	// Controller
+	authController := controller.NewAuthController(authService, &loadConfig)
+	workerController := controller.NewWorkerController(workerService)
+	organizationController := controller.NewOrganizationController(organizationService)
 	reportController := controller.NewReportController(reportService)
 
-	router := router.NewRouter(reportController)
+	router := router.NewRouter(&loadConfig, userRepository, authController, workerController, organizationController, reportController)
 
 	server := &http.Server{
 		Addr:    ":8080",
@@ -0,0 +1,49 @@
+package middleware
+
+import (
+	"net/http"
+	"reports/config"
+	"reports/model"
+	"reports/repository"
+	"reports/utils"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// DeserializeUser rejects requests without a valid token and stores the
+// authenticated user on both the gin context and the request context.
+func DeserializeUser(userRepository repository.UserRepository, config *config.Config) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		var token string
+
+		authorizationHeader := ctx.Request.Header.Get("Authorization")
+		fields := strings.Fields(authorizationHeader)
+		if len(fields) == 2 && strings.EqualFold(fields[0], "Bearer") {
+			token = fields[1]
+		} else if cookie, err := ctx.Cookie("token"); err == nil {
+			token = cookie
+		}
+
+		if token == "" {
+			ctx.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "You are not logged in"})
+			return
+		}
+
+		userId, err := utils.ValidateToken(token, config.TokenSecret)
+		if err != nil {
+			ctx.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "Invalid or expired token"})
+			return
+		}
+
+		user, err := userRepository.FindById(ctx.Request.Context(), userId)
+		if err != nil {
+			ctx.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "The user belonging to this token no longer exists"})
+			return
+		}
+
+		ctx.Set("currentUser", user)
+		ctx.Request = ctx.Request.WithContext(model.ContextWithUser(ctx.Request.Context(), user))
+		ctx.Next()
+	}
+}
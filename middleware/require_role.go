@@ -0,0 +1,29 @@
+package middleware
+
+import (
+	"net/http"
+	"reports/model"
+
+	"github.com/gin-gonic/gin"
+)
+
+// RequireRole only lets through users holding one of the given roles. It
+// must run after DeserializeUser.
+func RequireRole(roles ...model.Role) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		user, ok := model.UserFromContext(ctx.Request.Context())
+		if !ok {
+			ctx.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "You are not logged in"})
+			return
+		}
+
+		for _, role := range roles {
+			if user.Role == role {
+				ctx.Next()
+				return
+			}
+		}
+
+		ctx.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "You are not allowed to perform this action"})
+	}
+}
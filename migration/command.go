@@ -0,0 +1,88 @@
+package migration
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strconv"
+)
+
+// Usage describes the arguments of the migrate command
+const Usage = `usage: migrate <command>
+  up          apply every pending migration
+  down [n]    revert the last n migrations (default 1)
+  status      list the migrations and when they were applied
+  force <n>   record migrations up to n as applied without running them`
+
+// Run carries out the migrate command given its arguments, as in
+// "reports migrate down 2", writing what it did to out
+func Run(ctx context.Context, m *Migrator, args []string, out io.Writer) error {
+	if len(args) == 0 {
+		return fmt.Errorf("missing command\n%s", Usage)
+	}
+
+	switch args[0] {
+	case "up":
+		baseline, err := m.Baseline(ctx)
+		if err != nil {
+			return err
+		}
+		if baseline > 0 {
+			fmt.Fprintf(out, "recorded migrations up to %04d as already applied\n", baseline)
+		}
+
+		done, err := m.Up(ctx)
+		for _, migration := range done {
+			fmt.Fprintf(out, "applied %04d %s\n", migration.Version, migration.Name)
+		}
+		if err == nil && len(done) == 0 {
+			fmt.Fprintln(out, "the schema is up to date")
+		}
+		return err
+
+	case "down":
+		steps := 1
+		if len(args) > 1 {
+			n, err := strconv.Atoi(args[1])
+			if err != nil || n < 1 {
+				return fmt.Errorf("invalid number of migrations %q", args[1])
+			}
+			steps = n
+		}
+		done, err := m.Down(ctx, steps)
+		for _, migration := range done {
+			fmt.Fprintf(out, "reverted %04d %s\n", migration.Version, migration.Name)
+		}
+		return err
+
+	case "status":
+		statuses, err := m.Status(ctx)
+		if err != nil {
+			return err
+		}
+		for _, status := range statuses {
+			applied := "pending"
+			if status.AppliedAt != nil {
+				applied = "applied " + status.AppliedAt.Format("2006-01-02 15:04:05")
+			}
+			fmt.Fprintf(out, "%04d %-30s %s\n", status.Version, status.Name, applied)
+		}
+		return nil
+
+	case "force":
+		if len(args) < 2 {
+			return fmt.Errorf("missing version\n%s", Usage)
+		}
+		version, err := strconv.Atoi(args[1])
+		if err != nil || version < 0 {
+			return fmt.Errorf("invalid version %q", args[1])
+		}
+		if err := m.Force(ctx, version); err != nil {
+			return err
+		}
+		fmt.Fprintf(out, "recorded migrations up to %04d as applied\n", version)
+		return nil
+	}
+
+	return fmt.Errorf("unknown command %q\n%s", args[0], Usage)
+}
@@ -0,0 +1,403 @@
+// Package migration keeps the database schema in step with the code. The
+// migrations are SQL files embedded in the binary and numbered in the order
+// they apply: NNNN_name.up.sql makes a change and NNNN_name.down.sql undoes
+// it. Each runs in a transaction together with its row in schema_migrations,
+// so a failed migration leaves nothing behind.
+//
+// A database set up by hand from the former sql/*.sql files has tables but
+// no recorded migrations. Baseline, which "migrate up" and the startup
+// migration run first, recognizes the migrations its schema already has and
+// records them, so only the rest are applied. Should it guess wrong, record
+// the right ones with "migrate force N", N being the migration of the last
+// script that was applied to the database:
+//
+//	0001  report_table.sql as first written, with free-text columns
+//	0002  user_table.sql
+//	0003  alter_users_add_role.sql
+//	0004  alter_reports_unique_worker_month.sql
+//	0005  alter_reports_month_of_period.sql
+//	0006  alter_reports_worker_id.sql
+//	0007  alter_reports_church_hierarchy.sql
+//	0008  alter_reports_add_status.sql
+//	0009  report_revision_table.sql
+//	0010  alter_reports_soft_delete.sql
+//	0011  alter_reports_add_version.sql
+//	0012  alter_reports_tithes_money.sql
+//	0013  alter_reports_people.sql
+//	0014  alter_reports_search.sql
+//
+// The *_table.sql files of the last release that shipped them already
+// included every change, so a database created from them is at 14.
+//
+// The first migration cannot be reverted, as that would drop every report.
+package migration
+
+import (
+	"context"
+	"database/sql"
+	"embed"
+	"errors"
+	"fmt"
+	"io/fs"
+	"regexp"
+	"sort"
+	"strconv"
+	"time"
+)
+
+//go:embed sql/*.sql
+var files embed.FS
+
+// ErrSchemaOutOfDate is returned by Check when the database is behind or
+// ahead of the migrations of this build
+var ErrSchemaOutOfDate = errors.New("the database schema is out of date")
+
+// lockId keeps two servers from migrating the same database at once
+const lockId = 4170321
+
+var fileName = regexp.MustCompile(`^(\d+)_(\w+)\.(up|down)\.sql$`)
+
+type Migration struct {
+	Version int
+	Name    string
+
+	up   string
+	down string
+}
+
+// Status is a migration and when it was applied, if it was
+type Status struct {
+	*Migration
+	AppliedAt *time.Time
+}
+
+type Migrator struct {
+	db         *sql.DB
+	migrations []*Migration
+}
+
+func New(db *sql.DB) (*Migrator, error) {
+	migrations, err := load(files)
+	if err != nil {
+		return nil, err
+	}
+	return &Migrator{db: db, migrations: migrations}, nil
+}
+
+// load reads the migrations in version order
+func load(fsys fs.FS) ([]*Migration, error) {
+	entries, err := fs.ReadDir(fsys, "sql")
+	if err != nil {
+		return nil, err
+	}
+
+	byVersion := map[int]*Migration{}
+	for _, entry := range entries {
+		match := fileName.FindStringSubmatch(entry.Name())
+		if match == nil {
+			return nil, fmt.Errorf("migration %s: name must look like 0001_name.up.sql", entry.Name())
+		}
+
+		version, _ := strconv.Atoi(match[1])
+		migration, ok := byVersion[version]
+		if !ok {
+			migration = &Migration{Version: version, Name: match[2]}
+			byVersion[version] = migration
+		}
+		if migration.Name != match[2] {
+			return nil, fmt.Errorf("migration %d is named both %s and %s", version, migration.Name, match[2])
+		}
+
+		body, err := fs.ReadFile(fsys, "sql/"+entry.Name())
+		if err != nil {
+			return nil, err
+		}
+		script := &migration.up
+		if match[3] == "down" {
+			script = &migration.down
+		}
+		if *script != "" {
+			return nil, fmt.Errorf("migration %d has two %s files", version, match[3])
+		}
+		*script = string(body)
+	}
+
+	migrations := make([]*Migration, 0, len(byVersion))
+	for _, migration := range byVersion {
+		if migration.up == "" {
+			return nil, fmt.Errorf("migration %d has no up file", migration.Version)
+		}
+		migrations = append(migrations, migration)
+	}
+	sort.Slice(migrations, func(i, j int) bool { return migrations[i].Version < migrations[j].Version })
+
+	return migrations, nil
+}
+
+// Up applies every pending migration and returns them
+func (m *Migrator) Up(ctx context.Context) ([]*Migration, error) {
+	var done []*Migration
+	err := m.locked(ctx, func(conn *sql.Conn) error {
+		applied, err := appliedVersions(ctx, conn)
+		if err != nil {
+			return err
+		}
+
+		for _, migration := range m.migrations {
+			if _, ok := applied[migration.Version]; ok {
+				continue
+			}
+			if err := run(ctx, conn, migration.up, "INSERT INTO schema_migrations (version, name) VALUES ($1, $2)", migration.Version, migration.Name); err != nil {
+				return fmt.Errorf("migration %d %s: %w", migration.Version, migration.Name, err)
+			}
+			done = append(done, migration)
+		}
+		return nil
+	})
+	return done, err
+}
+
+// Down reverts the last steps applied migrations and returns them
+func (m *Migrator) Down(ctx context.Context, steps int) ([]*Migration, error) {
+	var done []*Migration
+	err := m.locked(ctx, func(conn *sql.Conn) error {
+		applied, err := appliedVersions(ctx, conn)
+		if err != nil {
+			return err
+		}
+
+		for i := len(m.migrations) - 1; i >= 0 && len(done) < steps; i-- {
+			migration := m.migrations[i]
+			if _, ok := applied[migration.Version]; !ok {
+				continue
+			}
+			if migration.down == "" {
+				return fmt.Errorf("migration %d %s cannot be reverted", migration.Version, migration.Name)
+			}
+			if err := run(ctx, conn, migration.down, "DELETE FROM schema_migrations WHERE version = $1", migration.Version); err != nil {
+				return fmt.Errorf("reverting migration %d %s: %w", migration.Version, migration.Name, err)
+			}
+			done = append(done, migration)
+		}
+		return nil
+	})
+	return done, err
+}
+
+// Force records the migrations up to version as applied and the later ones
+// as not, without running any of them
+func (m *Migrator) Force(ctx context.Context, version int) error {
+	if version != 0 && m.find(version) == nil {
+		return fmt.Errorf("there is no migration %d", version)
+	}
+
+	return m.locked(ctx, func(conn *sql.Conn) error {
+		return m.record(ctx, conn, version)
+	})
+}
+
+// baselineProbes recognize the migrations of a database set up by hand:
+// probe i holds once migration i+1 has been applied
+var baselineProbes = []string{
+	tableExists("reports"),
+	tableExists("users"),
+	columnExists("users", "role"),
+	`SELECT to_regclass('reports_worker_month_key') IS NOT NULL`,
+	`SELECT EXISTS (SELECT 1 FROM information_schema.columns WHERE table_name = 'reports' AND column_name = 'month_of' AND data_type = 'date')`,
+	tableExists("workers"),
+	tableExists("churches"),
+	columnExists("reports", "status"),
+	tableExists("report_revisions"),
+	columnExists("reports", "deleted_at"),
+	columnExists("reports", "version"),
+	columnExists("reports", "currency"),
+	columnExists("reports", "people"),
+	columnExists("reports", "search_vector"),
+}
+
+func tableExists(table string) string {
+	return `SELECT to_regclass('` + table + `') IS NOT NULL`
+}
+
+func columnExists(table, column string) string {
+	return `SELECT EXISTS (SELECT 1 FROM information_schema.columns WHERE table_name = '` + table + `' AND column_name = '` + column + `')`
+}
+
+// Baseline records the migrations a database set up by hand already has,
+// going by baselineProbes, and returns the last one. It does nothing, and
+// returns 0, once any migration is recorded or when the database is empty.
+func (m *Migrator) Baseline(ctx context.Context) (int, error) {
+	version := 0
+	err := m.locked(ctx, func(conn *sql.Conn) error {
+		applied, err := appliedVersions(ctx, conn)
+		if err != nil || len(applied) > 0 {
+			return err
+		}
+
+		for _, probe := range baselineProbes {
+			var present bool
+			if err := conn.QueryRowContext(ctx, probe).Scan(&present); err != nil {
+				return err
+			}
+			if !present {
+				break
+			}
+			version++
+		}
+
+		if version == 0 {
+			return nil
+		}
+		return m.record(ctx, conn, version)
+	})
+	return version, err
+}
+
+// record marks the migrations up to version as applied and the later ones
+// as not
+func (m *Migrator) record(ctx context.Context, conn *sql.Conn, version int) error {
+	tx, err := conn.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, "DELETE FROM schema_migrations"); err != nil {
+		return err
+	}
+	for _, migration := range m.migrations {
+		if migration.Version > version {
+			break
+		}
+		if _, err := tx.ExecContext(ctx, "INSERT INTO schema_migrations (version, name) VALUES ($1, $2)", migration.Version, migration.Name); err != nil {
+			return err
+		}
+	}
+	return tx.Commit()
+}
+
+// Status lists every migration, followed by any applied migration this
+// build does not know
+func (m *Migrator) Status(ctx context.Context) ([]*Status, error) {
+	var statuses []*Status
+	err := m.locked(ctx, func(conn *sql.Conn) error {
+		applied, err := appliedVersions(ctx, conn)
+		if err != nil {
+			return err
+		}
+
+		for _, migration := range m.migrations {
+			status := &Status{Migration: migration}
+			if at, ok := applied[migration.Version]; ok {
+				status.AppliedAt = &at
+				delete(applied, migration.Version)
+			}
+			statuses = append(statuses, status)
+		}
+
+		var unknown []int
+		for version := range applied {
+			unknown = append(unknown, version)
+		}
+		sort.Ints(unknown)
+		for _, version := range unknown {
+			at := applied[version]
+			statuses = append(statuses, &Status{Migration: &Migration{Version: version, Name: "unknown"}, AppliedAt: &at})
+		}
+		return nil
+	})
+	return statuses, err
+}
+
+// Check fails with ErrSchemaOutOfDate unless exactly the migrations of this
+// build are applied
+func (m *Migrator) Check(ctx context.Context) error {
+	statuses, err := m.Status(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, status := range statuses {
+		switch {
+		case status.AppliedAt == nil:
+			return fmt.Errorf("%w: migration %d %s is pending; run \"migrate up\"", ErrSchemaOutOfDate, status.Version, status.Name)
+		case m.find(status.Version) == nil:
+			return fmt.Errorf("%w: migration %d was applied by a newer build", ErrSchemaOutOfDate, status.Version)
+		}
+	}
+	return nil
+}
+
+func (m *Migrator) find(version int) *Migration {
+	for _, migration := range m.migrations {
+		if migration.Version == version {
+			return migration
+		}
+	}
+	return nil
+}
+
+// locked runs fn on one connection holding the migration lock, after making
+// sure schema_migrations exists
+func (m *Migrator) locked(ctx context.Context, fn func(conn *sql.Conn) error) error {
+	conn, err := m.db.Conn(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	if _, err := conn.ExecContext(ctx, "SELECT pg_advisory_lock($1)", lockId); err != nil {
+		return err
+	}
+	defer conn.ExecContext(context.Background(), "SELECT pg_advisory_unlock($1)", lockId)
+
+	_, err = conn.ExecContext(ctx, `
+		CREATE TABLE IF NOT EXISTS schema_migrations (
+			version INTEGER PRIMARY KEY,
+			name VARCHAR(100) NOT NULL,
+			applied_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+		)`)
+	if err != nil {
+		return err
+	}
+
+	return fn(conn)
+}
+
+// appliedVersions maps the applied versions to when they were applied
+func appliedVersions(ctx context.Context, conn *sql.Conn) (map[int]time.Time, error) {
+	rows, err := conn.QueryContext(ctx, "SELECT version, applied_at FROM schema_migrations")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	applied := map[int]time.Time{}
+	for rows.Next() {
+		var version int
+		var at time.Time
+		if err := rows.Scan(&version, &at); err != nil {
+			return nil, err
+		}
+		applied[version] = at
+	}
+	return applied, rows.Err()
+}
+
+// run executes a migration file and its bookkeeping statement in one
+// transaction
+func run(ctx context.Context, conn *sql.Conn, body, bookkeeping string, args ...interface{}) error {
+	tx, err := conn.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, body); err != nil {
+		return err
+	}
+	if _, err := tx.ExecContext(ctx, bookkeeping, args...); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
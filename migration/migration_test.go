@@ -0,0 +1,145 @@
+package migration
+
+import (
+	"io/fs"
+	"strings"
+	"testing"
+	"testing/fstest"
+)
+
+func TestLoad(t *testing.T) {
+	file := func(body string) *fstest.MapFile {
+		return &fstest.MapFile{Data: []byte(body)}
+	}
+
+	tests := []struct {
+		name    string
+		fsys    fstest.MapFS
+		want    []Migration
+		wantErr string
+	}{
+		{
+			name: "up and down files are paired in version order",
+			fsys: fstest.MapFS{
+				"sql/0002_users.up.sql":    file("CREATE TABLE users ();"),
+				"sql/0002_users.down.sql":  file("DROP TABLE users;"),
+				"sql/0001_reports.up.sql":  file("CREATE TABLE reports ();"),
+				"sql/0010_search.up.sql":   file("ALTER TABLE reports ADD search text;"),
+				"sql/0010_search.down.sql": file("ALTER TABLE reports DROP search;"),
+			},
+			want: []Migration{
+				{Version: 1, Name: "reports", up: "CREATE TABLE reports ();"},
+				{Version: 2, Name: "users", up: "CREATE TABLE users ();", down: "DROP TABLE users;"},
+				{Version: 10, Name: "search", up: "ALTER TABLE reports ADD search text;", down: "ALTER TABLE reports DROP search;"},
+			},
+		},
+		{
+			name: "no migrations",
+			fsys: fstest.MapFS{"sql": &fstest.MapFile{Mode: fs.ModeDir}},
+			want: []Migration{},
+		},
+		{
+			name: "one version with two names",
+			fsys: fstest.MapFS{
+				"sql/0001_reports.up.sql":   file("CREATE TABLE reports ();"),
+				"sql/0001_users.down.sql":   file("DROP TABLE users;"),
+				"sql/0002_users.up.sql":     file("CREATE TABLE users ();"),
+				"sql/0002_users.down.sql":   file("DROP TABLE users;"),
+				"sql/0001_reports.down.sql": file("DROP TABLE reports;"),
+			},
+			wantErr: "migration 1 is named both",
+		},
+		{
+			name: "one version written two ways",
+			fsys: fstest.MapFS{
+				"sql/0001_reports.up.sql": file("CREATE TABLE reports ();"),
+				"sql/1_reports.up.sql":    file("CREATE TABLE reports (id int);"),
+			},
+			wantErr: "migration 1 has two up files",
+		},
+		{
+			name: "down file without an up file",
+			fsys: fstest.MapFS{
+				"sql/0001_reports.up.sql":  file("CREATE TABLE reports ();"),
+				"sql/0002_users.down.sql":  file("DROP TABLE users;"),
+				"sql/0003_status.up.sql":   file("ALTER TABLE reports ADD status text;"),
+				"sql/0003_status.down.sql": file("ALTER TABLE reports DROP status;"),
+			},
+			wantErr: "migration 2 has no up file",
+		},
+		{
+			name: "empty up file",
+			fsys: fstest.MapFS{
+				"sql/0001_reports.up.sql":   file(""),
+				"sql/0001_reports.down.sql": file("DROP TABLE reports;"),
+			},
+			wantErr: "migration 1 has no up file",
+		},
+		{
+			name: "name without a version",
+			fsys: fstest.MapFS{
+				"sql/0001_reports.up.sql": file("CREATE TABLE reports ();"),
+				"sql/users.up.sql":        file("CREATE TABLE users ();"),
+			},
+			wantErr: "migration users.up.sql: name must look like",
+		},
+		{
+			name: "neither up nor down",
+			fsys: fstest.MapFS{
+				"sql/0001_reports.sql": file("CREATE TABLE reports ();"),
+			},
+			wantErr: "migration 0001_reports.sql: name must look like",
+		},
+		{
+			name:    "no sql directory",
+			fsys:    fstest.MapFS{},
+			wantErr: "open sql",
+		},
+	}
+
+	for _, tt := range tests {
+		migrations, err := load(tt.fsys)
+		if tt.wantErr != "" {
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("%s: load() error = %v, want %q", tt.name, err, tt.wantErr)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s: load() error = %v", tt.name, err)
+			continue
+		}
+
+		if len(migrations) != len(tt.want) {
+			t.Errorf("%s: load() returned %d migrations, want %d", tt.name, len(migrations), len(tt.want))
+			continue
+		}
+		for i, migration := range migrations {
+			if *migration != tt.want[i] {
+				t.Errorf("%s: migration %d = %+v, want %+v", tt.name, i, *migration, tt.want[i])
+			}
+		}
+	}
+}
+
+func TestEmbeddedMigrations(t *testing.T) {
+	migrations, err := load(files)
+	if err != nil {
+		t.Fatalf("load() error = %v", err)
+	}
+
+	for i, migration := range migrations {
+		if migration.Version != i+1 {
+			t.Errorf("migration %s is numbered %d, want %d", migration.Name, migration.Version, i+1)
+		}
+		// Every migration but the first, which creates the reports, can be reverted
+		if migration.down == "" && migration.Version != 1 {
+			t.Errorf("migration %d has no down file", migration.Version)
+		}
+	}
+
+	// Baseline recognizes at most the migrations there are
+	if len(baselineProbes) > len(migrations) {
+		t.Errorf("%d baseline probes for %d migrations", len(baselineProbes), len(migrations))
+	}
+}
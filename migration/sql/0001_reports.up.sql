@@ -1,4 +1,4 @@
-
+-- The reports table as it was first created by hand
 CREATE TABLE reports (
     id SERIAL PRIMARY KEY,
     month_of VARCHAR(100) NOT NULL,
@@ -0,0 +1,4 @@
+ALTER TABLE users
+    DROP COLUMN role,
+    DROP COLUMN worker_name,
+    DROP COLUMN areas;
@@ -0,0 +1,8 @@
+-- Adds role based access to the users table
+ALTER TABLE users
+    ADD COLUMN role VARCHAR(20) NOT NULL DEFAULT 'worker' CHECK (role IN ('admin', 'supervisor', 'worker')),
+    ADD COLUMN worker_name VARCHAR(100),
+    ADD COLUMN areas JSONB NOT NULL DEFAULT '[]';
+
+-- The first account is the one seeded from ADMIN_EMAIL on startup
+UPDATE users SET role = 'admin' WHERE id = (SELECT MIN(id) FROM users);
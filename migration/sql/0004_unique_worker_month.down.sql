@@ -0,0 +1 @@
+DROP INDEX reports_worker_month_key;
@@ -0,0 +1,8 @@
+-- One report per worker per month. Resolve any duplicates listed by this
+-- query before migrating:
+--
+--   SELECT LOWER(worker_name), LOWER(month_of), ARRAY_AGG(id ORDER BY id)
+--   FROM reports
+--   GROUP BY LOWER(worker_name), LOWER(month_of)
+--   HAVING COUNT(*) > 1;
+CREATE UNIQUE INDEX reports_worker_month_key ON reports (LOWER(worker_name), LOWER(month_of));
@@ -0,0 +1,11 @@
+-- month_of goes back to text, written as "2024-01"
+ALTER TABLE reports RENAME COLUMN month_of TO month_of_date;
+ALTER TABLE reports ADD COLUMN month_of VARCHAR(100);
+
+UPDATE reports SET month_of = TO_CHAR(month_of_date, 'YYYY-MM');
+
+-- Dropping the date column also drops its check and unique index
+ALTER TABLE reports DROP COLUMN month_of_date;
+ALTER TABLE reports ALTER COLUMN month_of SET NOT NULL;
+
+CREATE UNIQUE INDEX reports_worker_month_key ON reports (LOWER(worker_name), LOWER(month_of));
@@ -0,0 +1,70 @@
+-- Converts the free-text month_of column into a DATE holding the first day
+-- of the reported month. Recognizes "2024-01", "2024/1", "01/2024",
+-- "Jan 2024", "January, 2024", "Sept. 2024" and "2024 January"; fails
+-- without changes, listing the reports to fix, if any value cannot be
+-- parsed or two reports of a worker turn out to be for the same month.
+ALTER TABLE reports RENAME COLUMN month_of TO month_of_text;
+ALTER TABLE reports ADD COLUMN month_of DATE;
+
+-- The year and month of every report, NULL where the text is not one of
+-- the spellings above
+CREATE TEMPORARY TABLE report_months ON COMMIT DROP AS
+SELECT
+    id,
+    worker_name,
+    month_of_text,
+    CASE
+        WHEN t ~ '^\d{4}[-/]\d{1,2}$' OR t ~* '^\d{4}\s+[a-z]{3,}$'
+            THEN SUBSTRING(t FROM '^\d{4}')::integer
+        WHEN t ~ '^\d{1,2}[-/]\d{4}$' OR t ~* '^[a-z]{3,}\.?,?\s+\d{4}$'
+            THEN SUBSTRING(t FROM '\d{4}$')::integer
+    END AS year,
+    CASE
+        WHEN t ~ '^\d{4}[-/]\d{1,2}$'
+            THEN SUBSTRING(t FROM '\d{1,2}$')::integer
+        WHEN t ~ '^\d{1,2}[-/]\d{4}$'
+            THEN SUBSTRING(t FROM '^\d{1,2}')::integer
+        WHEN t ~* '^[a-z]{3,}\.?,?\s+\d{4}$' OR t ~* '^\d{4}\s+[a-z]{3,}$'
+            THEN ARRAY_POSITION(
+                ARRAY['jan', 'feb', 'mar', 'apr', 'may', 'jun', 'jul', 'aug', 'sep', 'oct', 'nov', 'dec'],
+                LEFT(SUBSTRING(LOWER(t) FROM '[a-z]+'), 3)
+            )
+    END AS month
+FROM reports, LATERAL (SELECT TRIM(month_of_text) AS t) trimmed;
+
+DO $$
+DECLARE
+    problems TEXT;
+BEGIN
+    SELECT STRING_AGG(FORMAT('report %s (%s)', id, month_of_text), ', ' ORDER BY id) INTO problems
+    FROM report_months
+    WHERE year IS NULL OR year < 1 OR month IS NULL OR month NOT BETWEEN 1 AND 12;
+
+    IF problems IS NOT NULL THEN
+        RAISE EXCEPTION 'cannot parse month_of of %', problems;
+    END IF;
+
+    -- "2024-03" and "March 2024" are the same month
+    SELECT STRING_AGG(FORMAT('reports %s of %s for %s-%s', ids, worker_name, year, LPAD(month::text, 2, '0')), '; ') INTO problems
+    FROM (
+        SELECT MIN(worker_name) AS worker_name, year, month, STRING_AGG(id::text, ', ' ORDER BY id) AS ids
+        FROM report_months
+        GROUP BY LOWER(worker_name), year, month
+        HAVING COUNT(*) > 1
+    ) duplicates;
+
+    IF problems IS NOT NULL THEN
+        RAISE EXCEPTION 'reports of the same worker are for the same month; keep one of each: %', problems;
+    END IF;
+END $$;
+
+UPDATE reports t SET month_of = MAKE_DATE(m.year, m.month, 1)
+FROM report_months m
+WHERE m.id = t.id;
+
+-- Dropping the text column also drops the old unique index
+ALTER TABLE reports DROP COLUMN month_of_text;
+ALTER TABLE reports ALTER COLUMN month_of SET NOT NULL;
+ALTER TABLE reports ADD CONSTRAINT reports_month_of_first_day CHECK (EXTRACT(DAY FROM month_of) = 1);
+
+CREATE UNIQUE INDEX reports_worker_month_key ON reports (LOWER(worker_name), month_of);
@@ -0,0 +1,23 @@
+-- Reports and users get their worker's name back, and the workers table
+-- goes away
+ALTER TABLE reports ADD COLUMN worker_name VARCHAR(100);
+
+UPDATE reports t SET worker_name = w.name
+FROM workers w
+WHERE w.id = t.worker_id;
+
+ALTER TABLE reports ALTER COLUMN worker_name SET NOT NULL;
+
+-- Dropping worker_id also drops the unique index on it
+ALTER TABLE reports DROP COLUMN worker_id;
+CREATE UNIQUE INDEX reports_worker_month_key ON reports (LOWER(worker_name), month_of);
+
+ALTER TABLE users ADD COLUMN worker_name VARCHAR(100);
+
+UPDATE users u SET worker_name = w.name
+FROM workers w
+WHERE w.id = u.worker_id;
+
+ALTER TABLE users DROP COLUMN worker_id;
+
+DROP TABLE workers;
@@ -0,0 +1,65 @@
+-- Replaces the free-text worker_name on reports and users with a reference
+-- to the new workers table. One worker is created per distinct spelling,
+-- ignoring case and surrounding whitespace; near-duplicates such as typos
+-- are then merged through GET /api/workers/duplicates and
+-- POST /api/workers/:workerId/merge.
+CREATE TABLE workers (
+    id SERIAL PRIMARY KEY,
+    name VARCHAR(100) NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE UNIQUE INDEX workers_name_key ON workers (LOWER(name));
+
+INSERT INTO workers (name)
+SELECT DISTINCT ON (LOWER(TRIM(name))) TRIM(name)
+FROM (
+    SELECT worker_name AS name, created_at FROM reports
+    UNION ALL
+    SELECT worker_name AS name, created_at FROM users WHERE worker_name IS NOT NULL
+) names
+WHERE TRIM(name) <> ''
+ORDER BY LOWER(TRIM(name)), created_at;
+
+-- Reports
+ALTER TABLE reports ADD COLUMN worker_id INTEGER REFERENCES workers(id);
+
+UPDATE reports t SET worker_id = w.id
+FROM workers w
+WHERE LOWER(w.name) = LOWER(TRIM(t.worker_name));
+
+ALTER TABLE reports ALTER COLUMN worker_id SET NOT NULL;
+
+-- Spellings that differ only in surrounding whitespace become one worker,
+-- which may then have two reports for a month
+DO $$
+DECLARE
+    problems TEXT;
+BEGIN
+    SELECT STRING_AGG(FORMAT('reports %s of %s for %s', ids, name, TO_CHAR(month_of, 'YYYY-MM')), '; ') INTO problems
+    FROM (
+        SELECT MIN(w.name) AS name, t.month_of, STRING_AGG(t.id::text, ', ' ORDER BY t.id) AS ids
+        FROM reports t
+        JOIN workers w ON w.id = t.worker_id
+        GROUP BY t.worker_id, t.month_of
+        HAVING COUNT(*) > 1
+    ) duplicates;
+
+    IF problems IS NOT NULL THEN
+        RAISE EXCEPTION 'reports of the same worker are for the same month; keep one of each: %', problems;
+    END IF;
+END $$;
+
+-- Dropping worker_name also drops the old unique index
+ALTER TABLE reports DROP COLUMN worker_name;
+CREATE UNIQUE INDEX reports_worker_month_key ON reports (worker_id, month_of);
+
+-- Users
+ALTER TABLE users ADD COLUMN worker_id INTEGER REFERENCES workers(id);
+
+UPDATE users u SET worker_id = w.id
+FROM workers w
+WHERE LOWER(w.name) = LOWER(TRIM(u.worker_name));
+
+ALTER TABLE users DROP COLUMN worker_name;
@@ -0,0 +1,33 @@
+-- Reports and supervisors get the names of their church and areas back,
+-- and the hierarchy tables go away
+ALTER TABLE reports
+    ADD COLUMN area_of_assignment VARCHAR(100),
+    ADD COLUMN name_of_church VARCHAR(100);
+
+UPDATE reports t SET area_of_assignment = a.name, name_of_church = c.name
+FROM churches c
+JOIN areas a ON a.id = c.area_id
+WHERE c.id = t.church_id;
+
+ALTER TABLE reports
+    ALTER COLUMN area_of_assignment SET NOT NULL,
+    ALTER COLUMN name_of_church SET NOT NULL;
+
+ALTER TABLE reports DROP COLUMN church_id;
+
+ALTER TABLE users ADD COLUMN areas JSONB NOT NULL DEFAULT '[]';
+
+UPDATE users u SET areas = COALESCE((
+    SELECT jsonb_agg(a.name ORDER BY a.name)
+    FROM areas a
+    WHERE a.id IN (
+        SELECT value::integer FROM jsonb_array_elements_text(u.area_ids)
+    )
+), '[]');
+
+ALTER TABLE users DROP COLUMN area_ids;
+
+DROP TABLE churches;
+DROP TABLE areas;
+DROP TABLE districts;
+DROP TABLE regions;
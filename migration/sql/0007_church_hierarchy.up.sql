@@ -0,0 +1,101 @@
+-- Replaces the free-text area_of_assignment and name_of_church on reports
+-- with a reference to the new churches table, and the area names kept on
+-- supervisors with area ids. Existing areas are placed under an
+-- "Unassigned" region and district; move them with PUT /api/areas/:id once
+-- the real districts exist. Reports with a blank area or church go to an
+-- area or church named "Unassigned", so that every report gets a church.
+CREATE TABLE regions (
+    id SERIAL PRIMARY KEY,
+    name VARCHAR(100) NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE UNIQUE INDEX regions_name_key ON regions (LOWER(name));
+
+CREATE TABLE districts (
+    id SERIAL PRIMARY KEY,
+    region_id INTEGER NOT NULL REFERENCES regions(id),
+    name VARCHAR(100) NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE UNIQUE INDEX districts_name_key ON districts (region_id, LOWER(name));
+
+CREATE TABLE areas (
+    id SERIAL PRIMARY KEY,
+    district_id INTEGER NOT NULL REFERENCES districts(id),
+    name VARCHAR(100) NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE UNIQUE INDEX areas_name_key ON areas (district_id, LOWER(name));
+
+CREATE TABLE churches (
+    id SERIAL PRIMARY KEY,
+    area_id INTEGER NOT NULL REFERENCES areas(id),
+    name VARCHAR(100) NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE UNIQUE INDEX churches_name_key ON churches (area_id, LOWER(name));
+
+INSERT INTO regions (name) VALUES ('Unassigned');
+INSERT INTO districts (region_id, name) SELECT id, 'Unassigned' FROM regions;
+
+-- The area and church of every report, blanks replaced
+CREATE TEMPORARY TABLE report_churches ON COMMIT DROP AS
+SELECT
+    id,
+    created_at,
+    COALESCE(NULLIF(TRIM(area_of_assignment), ''), 'Unassigned') AS area,
+    COALESCE(NULLIF(TRIM(name_of_church), ''), 'Unassigned') AS church
+FROM reports;
+
+-- One area per distinct spelling, ignoring case and surrounding whitespace
+INSERT INTO areas (district_id, name)
+SELECT (SELECT id FROM districts), name
+FROM (
+    SELECT DISTINCT ON (LOWER(area)) area AS name
+    FROM report_churches
+    ORDER BY LOWER(area), created_at
+) names;
+
+INSERT INTO churches (area_id, name)
+SELECT a.id, c.name
+FROM (
+    SELECT DISTINCT ON (LOWER(area), LOWER(church)) area, church AS name
+    FROM report_churches
+    ORDER BY LOWER(area), LOWER(church), created_at
+) c
+JOIN areas a ON LOWER(a.name) = LOWER(c.area);
+
+-- Reports
+ALTER TABLE reports ADD COLUMN church_id INTEGER REFERENCES churches(id);
+
+UPDATE reports t SET church_id = c.id
+FROM report_churches r
+JOIN areas a ON LOWER(a.name) = LOWER(r.area)
+JOIN churches c ON c.area_id = a.id AND LOWER(c.name) = LOWER(r.church)
+WHERE r.id = t.id;
+
+ALTER TABLE reports ALTER COLUMN church_id SET NOT NULL;
+
+ALTER TABLE reports DROP COLUMN area_of_assignment;
+ALTER TABLE reports DROP COLUMN name_of_church;
+
+-- Users
+ALTER TABLE users ADD COLUMN area_ids JSONB NOT NULL DEFAULT '[]';
+
+UPDATE users u SET area_ids = COALESCE((
+    SELECT jsonb_agg(a.id ORDER BY a.id)
+    FROM areas a
+    WHERE LOWER(a.name) IN (
+        SELECT LOWER(TRIM(value)) FROM jsonb_array_elements_text(u.areas)
+    )
+), '[]');
+
+ALTER TABLE users DROP COLUMN areas;
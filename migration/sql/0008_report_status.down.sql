@@ -0,0 +1,7 @@
+-- Dropping status also drops its index
+ALTER TABLE reports
+    DROP COLUMN status,
+    DROP COLUMN review_note,
+    DROP COLUMN submitted_at,
+    DROP COLUMN reviewed_at,
+    DROP COLUMN reviewed_by;
@@ -0,0 +1,13 @@
+-- Adds the draft / submitted / approved / returned review workflow. Reports
+-- saved before the workflow existed were final, so they start as submitted
+-- and wait for a supervisor's review.
+ALTER TABLE reports
+    ADD COLUMN status VARCHAR(20) NOT NULL DEFAULT 'draft' CHECK (status IN ('draft', 'submitted', 'approved', 'returned')),
+    ADD COLUMN review_note TEXT NOT NULL DEFAULT '',
+    ADD COLUMN submitted_at TIMESTAMP WITH TIME ZONE,
+    ADD COLUMN reviewed_at TIMESTAMP WITH TIME ZONE,
+    ADD COLUMN reviewed_by INTEGER REFERENCES users(id);
+
+UPDATE reports SET status = 'submitted', submitted_at = created_at;
+
+CREATE INDEX reports_status_idx ON reports (status);
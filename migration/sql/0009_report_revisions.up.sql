@@ -0,0 +1,12 @@
+-- report_id has no foreign key so the history of a deleted report is kept
+CREATE TABLE report_revisions (
+    id SERIAL PRIMARY KEY,
+    report_id INTEGER NOT NULL,
+    action VARCHAR(20) NOT NULL CHECK (action IN ('create', 'update', 'delete', 'submit', 'approve', 'return')),
+    actor_id INTEGER REFERENCES users(id),
+    changes JSONB NOT NULL DEFAULT '[]',
+    snapshot JSONB,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX report_revisions_report_id_idx ON report_revisions (report_id, id);
@@ -0,0 +1,21 @@
+-- Without deleted_at the reports in the trash would come back, so the trash
+-- has to be restored or purged first
+DO $$
+BEGIN
+    IF EXISTS (SELECT 1 FROM reports WHERE deleted_at IS NOT NULL) THEN
+        RAISE EXCEPTION 'the trash is not empty; restore or purge its reports first';
+    END IF;
+END $$;
+
+-- Dropping deleted_at also drops the partial indexes on it
+ALTER TABLE reports
+    DROP COLUMN deleted_at,
+    DROP COLUMN deleted_by;
+
+CREATE UNIQUE INDEX reports_worker_month_key ON reports (worker_id, month_of);
+
+-- Revisions already recorded for restores and purges are kept; the check
+-- only applies to new ones
+ALTER TABLE report_revisions DROP CONSTRAINT report_revisions_action_check;
+ALTER TABLE report_revisions ADD CONSTRAINT report_revisions_action_check
+    CHECK (action IN ('create', 'update', 'delete', 'submit', 'approve', 'return')) NOT VALID;
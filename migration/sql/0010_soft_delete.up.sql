@@ -0,0 +1,14 @@
+-- Deleting a report moves it to the trash instead of removing the row. The
+-- one-report-per-month rule only applies to reports outside the trash.
+ALTER TABLE reports
+    ADD COLUMN deleted_at TIMESTAMP WITH TIME ZONE,
+    ADD COLUMN deleted_by INTEGER REFERENCES users(id);
+
+DROP INDEX reports_worker_month_key;
+CREATE UNIQUE INDEX reports_worker_month_key ON reports (worker_id, month_of) WHERE deleted_at IS NULL;
+
+CREATE INDEX reports_deleted_at_idx ON reports (deleted_at) WHERE deleted_at IS NOT NULL;
+
+ALTER TABLE report_revisions DROP CONSTRAINT report_revisions_action_check;
+ALTER TABLE report_revisions ADD CONSTRAINT report_revisions_action_check
+    CHECK (action IN ('create', 'update', 'delete', 'submit', 'approve', 'return', 'restore', 'purge'));
@@ -0,0 +1,3 @@
+-- Every write bumps version; updates send back the version they read
+-- (If-Match) and fail when it no longer matches.
+ALTER TABLE reports ADD COLUMN version INTEGER NOT NULL DEFAULT 1;
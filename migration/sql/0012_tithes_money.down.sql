@@ -0,0 +1,16 @@
+-- The weekly amounts go back to JSON numbers. Without the currency column
+-- every amount reads as pesos, so reports in other currencies stop it.
+DO $$
+BEGIN
+    IF EXISTS (SELECT 1 FROM reports WHERE currency <> 'PHP') THEN
+        RAISE EXCEPTION 'some reports are not in PHP; their amounts cannot be kept without a currency';
+    END IF;
+END $$;
+
+UPDATE reports SET tithes_and_offerings = (
+    SELECT COALESCE(jsonb_agg(value::numeric ORDER BY ordinality), '[]')
+    FROM jsonb_array_elements_text(tithes_and_offerings) WITH ORDINALITY
+)
+WHERE jsonb_typeof(tithes_and_offerings) = 'array';
+
+ALTER TABLE reports DROP COLUMN currency;
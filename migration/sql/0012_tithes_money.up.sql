@@ -0,0 +1,9 @@
+-- Tithes and offerings become exact amounts: the weekly values are stored
+-- as decimal strings such as "1500.00", in the report's currency.
+ALTER TABLE reports ADD COLUMN currency VARCHAR(3) NOT NULL DEFAULT 'PHP';
+
+UPDATE reports SET tithes_and_offerings = (
+    SELECT COALESCE(jsonb_agg(to_char(value::numeric, 'FM9999999999990.00') ORDER BY ordinality), '[]')
+    FROM jsonb_array_elements_text(tithes_and_offerings) WITH ORDINALITY
+)
+WHERE jsonb_typeof(tithes_and_offerings) = 'array';
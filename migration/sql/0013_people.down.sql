@@ -0,0 +1,9 @@
+-- Person entries go back to bare names; their category, contact and date
+-- are dropped
+UPDATE reports SET people = (
+    SELECT COALESCE(jsonb_agg(value->'name' ORDER BY ordinality), '[]')
+    FROM jsonb_array_elements(people) WITH ORDINALITY
+)
+WHERE jsonb_typeof(people) = 'array';
+
+ALTER TABLE reports RENAME COLUMN people TO names;
@@ -0,0 +1,10 @@
+-- The names of people reached become person entries with a category,
+-- optional contact and date. Existing names carry over without a category,
+-- which has to be filled in before the report is submitted again.
+ALTER TABLE reports RENAME COLUMN names TO people;
+
+UPDATE reports SET people = (
+    SELECT COALESCE(jsonb_agg(jsonb_build_object('name', value) ORDER BY ordinality), '[]')
+    FROM jsonb_array_elements_text(people) WITH ORDINALITY
+)
+WHERE jsonb_typeof(people) = 'array';
@@ -0,0 +1,2 @@
+-- Dropping the column also drops its index
+ALTER TABLE reports DROP COLUMN search_vector;
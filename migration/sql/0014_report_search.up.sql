@@ -0,0 +1,10 @@
+-- Full-text search over the narrative, challenges and prayer requests. The
+-- vector is generated from the three columns, so it never goes stale.
+ALTER TABLE reports ADD COLUMN search_vector TSVECTOR GENERATED ALWAYS AS (
+    to_tsvector('english',
+        coalesce(narrative_report, '') || ' ' ||
+        coalesce(challenges_and_problem_encountered, '') || ' ' ||
+        coalesce(prayer_request, ''))
+) STORED;
+
+CREATE INDEX reports_search_idx ON reports USING GIN (search_vector);
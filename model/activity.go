@@ -0,0 +1,62 @@
+package model
+
+type ActivityKind string
+
+const (
+	// Attendance activities are weekly head counts
+	KindAttendance ActivityKind = "attendance"
+	// Outreach activities are weekly counts of the worker's own ministry
+	KindOutreach ActivityKind = "outreach"
+)
+
+// Activity describes one weekly array on a report. Key is both the JSON
+// field and the database column.
+type Activity struct {
+	Key    string
+	Label  string
+	Kind   ActivityKind
+	Values func(r *Report) []int
+}
+
+// Activities lists every weekly array in the order of the printed report
+var Activities = []Activity{
+	{"worship_service", "Worship Service", KindAttendance, func(r *Report) []int { return r.WorshipService }},
+	{"sunday_school", "Sunday School", KindAttendance, func(r *Report) []int { return r.SundaySchool }},
+	{"prayer_meetings", "Prayer Meetings", KindAttendance, func(r *Report) []int { return r.PrayerMeetings }},
+	{"bible_studies", "Bible Studies", KindAttendance, func(r *Report) []int { return r.BibleStudies }},
+	{"mens_fellowships", "Mens Fellowships", KindAttendance, func(r *Report) []int { return r.MensFellowships }},
+	{"womens_fellowships", "Womens Fellowships", KindAttendance, func(r *Report) []int { return r.WomensFellowships }},
+	{"youth_fellowships", "Youth Fellowships", KindAttendance, func(r *Report) []int { return r.YouthFellowships }},
+	{"child_fellowships", "Child Fellowships", KindAttendance, func(r *Report) []int { return r.ChildFellowships }},
+	{"outreach", "Outreach", KindAttendance, func(r *Report) []int { return r.Outreach }},
+	{"training_or_seminars", "Training Or Seminars", KindAttendance, func(r *Report) []int { return r.TrainingOrSeminars }},
+	{"leadership_conferences", "Leadership Conferences", KindAttendance, func(r *Report) []int { return r.LeadershipConferences }},
+	{"leadership_training", "Leadership Training", KindAttendance, func(r *Report) []int { return r.LeadershipTraining }},
+	{"others", "Others", KindAttendance, func(r *Report) []int { return r.Others }},
+	{"family_days", "Family Days", KindAttendance, func(r *Report) []int { return r.FamilyDays }},
+	{"home_visited", "Home Visited", KindOutreach, func(r *Report) []int { return r.HomeVisited }},
+	{"bible_study_or_group_led", "Bible Study Or Group Led", KindOutreach, func(r *Report) []int { return r.BibleStudyOrGroupLed }},
+	{"sermon_or_message_preached", "Sermon Or Message Preached", KindOutreach, func(r *Report) []int { return r.SermonOrMessagePreached }},
+	{"person_newly_contacted", "Person Newly Contacted", KindOutreach, func(r *Report) []int { return r.PersonNewlyContacted }},
+	{"person_followed_up", "Person Followed Up", KindOutreach, func(r *Report) []int { return r.PersonFollowedUp }},
+	{"person_led_to_christ", "Person Led To Christ", KindOutreach, func(r *Report) []int { return r.PersonLedToChrist }},
+}
+
+// FindActivity returns the activity with the given key
+func FindActivity(key string) (Activity, bool) {
+	for _, activity := range Activities {
+		if activity.Key == key {
+			return activity, true
+		}
+	}
+	return Activity{}, false
+}
+
+// Sum adds up the weekly values
+func Sum(values []int) int {
+	total := 0
+	for _, v := range values {
+		total += v
+	}
+	return total
+}
@@ -0,0 +1,140 @@
+package model
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"time"
+)
+
+// AnnualOutreachTotals are the counters totalled over the year on the
+// annual report
+var AnnualOutreachTotals = []string{"home_visited", "person_newly_contacted", "person_followed_up", "person_led_to_christ"}
+
+// AnnualActivity holds the weekly average of every month of the year for
+// one activity. Months without a report are nil.
+type AnnualActivity struct {
+	Key     string       `json:"key"`
+	Label   string       `json:"label"`
+	Kind    ActivityKind `json:"kind"`
+	Monthly []*float64   `json:"monthly"`
+	Average float64      `json:"average"`
+	Total   int          `json:"total"`
+}
+
+type AnnualTotal struct {
+	Key   string `json:"key"`
+	Label string `json:"label"`
+	Total int    `json:"total"`
+}
+
+// AnnualReport is the full-year or year-to-date report of a worker, built
+// from the monthly reports up to and including Through
+type AnnualReport struct {
+	WorkerId                        int               `json:"worker_id"`
+	WorkerName                      string            `json:"worker_name"`
+	Year                            int               `json:"year"`
+	Through                         Period            `json:"through"`
+	ReportCount                     int               `json:"report_count"`
+	MonthsReported                  []Period          `json:"months_reported"`
+	Activities                      []*AnnualActivity `json:"activities"`
+	OutreachTotals                  []*AnnualTotal    `json:"outreach_totals"`
+	Offerings                       map[string]Money  `json:"tithes_and_offerings_total"`
+	NarrativeReport                 string            `json:"narrative_report"`
+	ChallengesAndProblemEncountered string            `json:"challenges_and_problem_encountered"`
+	PrayerRequest                   string            `json:"prayer_request"`
+}
+
+// BuildAnnualReport lays the worker's reports out month by month. Reports
+// outside the year or after through are ignored.
+func BuildAnnualReport(worker *Worker, year int, through Period, reports []*Report) *AnnualReport {
+	annual := &AnnualReport{
+		WorkerId:       worker.Id,
+		WorkerName:     worker.Name,
+		Year:           year,
+		Through:        through,
+		MonthsReported: []Period{},
+		Activities:     make([]*AnnualActivity, 0, len(Activities)),
+		OutreachTotals: make([]*AnnualTotal, 0, len(AnnualOutreachTotals)),
+	}
+
+	var byMonth [12]*Report
+	for _, report := range reports {
+		if report.MonthOf.Year != year || through.Before(report.MonthOf) {
+			continue
+		}
+		byMonth[report.MonthOf.Month-1] = report
+	}
+
+	var reported []*Report
+	var narratives, challenges, prayers []string
+	for _, report := range byMonth {
+		if report == nil {
+			continue
+		}
+		reported = append(reported, report)
+		annual.ReportCount++
+		annual.MonthsReported = append(annual.MonthsReported, report.MonthOf)
+		narratives = appendNarrative(narratives, report.MonthOf, report.NarrativeReport)
+		challenges = appendNarrative(challenges, report.MonthOf, report.ChallengesAndProblemEncountered)
+		prayers = appendNarrative(prayers, report.MonthOf, report.PrayerRequest)
+	}
+	annual.NarrativeReport = strings.Join(narratives, "\n\n")
+	annual.ChallengesAndProblemEncountered = strings.Join(challenges, "\n\n")
+	annual.PrayerRequest = strings.Join(prayers, "\n\n")
+	annual.Offerings = SumOfferings(reported)
+
+	for _, activity := range Activities {
+		row := &AnnualActivity{
+			Key:     activity.Key,
+			Label:   activity.Label,
+			Kind:    activity.Kind,
+			Monthly: make([]*float64, 12),
+		}
+
+		var sumOfAverages float64
+		for i, report := range byMonth {
+			if report == nil {
+				continue
+			}
+			values := activity.Values(report)
+			average := CalculateAverage(values)
+			row.Monthly[i] = &average
+			sumOfAverages += average
+			row.Total += Sum(values)
+		}
+		if annual.ReportCount > 0 {
+			row.Average = math.Round(sumOfAverages/float64(annual.ReportCount)*100) / 100
+		}
+
+		annual.Activities = append(annual.Activities, row)
+	}
+
+	for _, key := range AnnualOutreachTotals {
+		for _, row := range annual.Activities {
+			if row.Key == key {
+				annual.OutreachTotals = append(annual.OutreachTotals, &AnnualTotal{Key: row.Key, Label: row.Label, Total: row.Total})
+			}
+		}
+	}
+
+	return annual
+}
+
+func appendNarrative(narratives []string, monthOf Period, text string) []string {
+	text = strings.TrimSpace(text)
+	if text == "" {
+		return narratives
+	}
+	return append(narratives, fmt.Sprintf("%s: %s", monthOf.Label(), text))
+}
+
+// AnnualThrough returns the last month covered by a year-to-date report:
+// the current month for the current year and December for past years
+func AnnualThrough(year int, now time.Time) Period {
+	current := PeriodOf(now)
+	if year == current.Year {
+		return current
+	}
+	return NewPeriod(year, time.December)
+}
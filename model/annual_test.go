@@ -0,0 +1,148 @@
+package model
+
+import (
+	"reflect"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func TestBuildAnnualReport(t *testing.T) {
+	worker := &Worker{Id: 5, Name: "Juan Cruz"}
+
+	tests := []struct {
+		name        string
+		through     Period
+		reports     []*Report
+		wantMonths  []Period
+		wantMonthly []*float64
+		wantAverage float64
+		wantTotal   int
+		// Total of home visits over the year
+		wantVisits    int
+		wantOfferings map[string]Money
+	}{
+		{
+			name:          "no reports",
+			through:       NewPeriod(2024, time.December),
+			wantMonths:    []Period{},
+			wantMonthly:   make([]*float64, 12),
+			wantOfferings: map[string]Money{},
+		},
+		{
+			name:    "months without reports are left out of the average",
+			through: NewPeriod(2024, time.December),
+			reports: []*Report{
+				{MonthOf: NewPeriod(2024, time.March), Currency: "PHP", WorshipService: []int{40, 42}, HomeVisited: []int{2, 3}},
+				{MonthOf: NewPeriod(2024, time.January), Currency: "PHP", WorshipService: []int{30, 30, 30, 30}, HomeVisited: []int{1}},
+				{MonthOf: NewPeriod(2024, time.June), Currency: "PHP", WorshipService: []int{50}},
+			},
+			wantMonths: []Period{NewPeriod(2024, time.January), NewPeriod(2024, time.March), NewPeriod(2024, time.June)},
+			wantMonthly: []*float64{
+				floatPtr(30), nil, floatPtr(41), nil, nil, floatPtr(50),
+				nil, nil, nil, nil, nil, nil,
+			},
+			// Over the three months reported, not all twelve (10.08)
+			wantAverage:   40.33,
+			wantTotal:     252,
+			wantVisits:    6,
+			wantOfferings: map[string]Money{},
+		},
+		{
+			name:    "other years and months after through are ignored",
+			through: NewPeriod(2024, time.April),
+			reports: []*Report{
+				{MonthOf: NewPeriod(2023, time.February), WorshipService: []int{99}, HomeVisited: []int{9}},
+				{MonthOf: NewPeriod(2024, time.February), WorshipService: []int{20}, HomeVisited: []int{4}},
+				{MonthOf: NewPeriod(2024, time.May), WorshipService: []int{99}, HomeVisited: []int{9}},
+				{MonthOf: NewPeriod(2025, time.February), WorshipService: []int{99}, HomeVisited: []int{9}},
+			},
+			wantMonths: []Period{NewPeriod(2024, time.February)},
+			wantMonthly: []*float64{
+				nil, floatPtr(20), nil, nil, nil, nil,
+				nil, nil, nil, nil, nil, nil,
+			},
+			wantAverage:   20,
+			wantTotal:     20,
+			wantVisits:    4,
+			wantOfferings: map[string]Money{},
+		},
+		{
+			name:    "offerings are totalled per currency",
+			through: NewPeriod(2024, time.December),
+			reports: []*Report{
+				{MonthOf: NewPeriod(2024, time.January), Currency: "PHP", WorshipService: []int{10}, TithesAndOfferings: []Money{150000, 25050}},
+				{MonthOf: NewPeriod(2024, time.February), Currency: "USD", WorshipService: []int{10}, TithesAndOfferings: []Money{2500}},
+				{MonthOf: NewPeriod(2024, time.March), Currency: "PHP", WorshipService: []int{10}, TithesAndOfferings: []Money{10000}},
+				// Another year's offerings are not counted
+				{MonthOf: NewPeriod(2023, time.March), Currency: "PHP", WorshipService: []int{10}, TithesAndOfferings: []Money{99999}},
+			},
+			wantMonths: []Period{NewPeriod(2024, time.January), NewPeriod(2024, time.February), NewPeriod(2024, time.March)},
+			wantMonthly: []*float64{
+				floatPtr(10), floatPtr(10), floatPtr(10), nil, nil, nil,
+				nil, nil, nil, nil, nil, nil,
+			},
+			wantAverage:   10,
+			wantTotal:     30,
+			wantOfferings: map[string]Money{"PHP": 185050, "USD": 2500},
+		},
+	}
+
+	for _, tt := range tests {
+		annual := BuildAnnualReport(worker, 2024, tt.through, tt.reports)
+
+		if annual.WorkerId != worker.Id || annual.WorkerName != worker.Name || annual.Year != 2024 || annual.Through != tt.through {
+			t.Errorf("%s: annual worker, year = %d %q %d %v", tt.name, annual.WorkerId, annual.WorkerName, annual.Year, annual.Through)
+		}
+		if annual.ReportCount != len(tt.wantMonths) || !reflect.DeepEqual(annual.MonthsReported, tt.wantMonths) {
+			t.Errorf("%s: %d reports for %v, want %v", tt.name, annual.ReportCount, annual.MonthsReported, tt.wantMonths)
+		}
+		if len(annual.Activities) != len(Activities) {
+			t.Fatalf("%s: %d activities, want %d", tt.name, len(annual.Activities), len(Activities))
+		}
+
+		worship := annual.Activities[0]
+		if worship.Key != "worship_service" {
+			t.Fatalf("%s: first activity = %q, want worship_service", tt.name, worship.Key)
+		}
+		if !reflect.DeepEqual(worship.Monthly, tt.wantMonthly) {
+			t.Errorf("%s: worship service monthly = %v, want %v", tt.name, formatMonthly(worship.Monthly), formatMonthly(tt.wantMonthly))
+		}
+		if worship.Average != tt.wantAverage || worship.Total != tt.wantTotal {
+			t.Errorf("%s: worship service average %v total %d, want %v and %d",
+				tt.name, worship.Average, worship.Total, tt.wantAverage, tt.wantTotal)
+		}
+
+		var keys []string
+		for _, total := range annual.OutreachTotals {
+			keys = append(keys, total.Key)
+			if total.Key == "home_visited" && total.Total != tt.wantVisits {
+				t.Errorf("%s: home visited total = %d, want %d", tt.name, total.Total, tt.wantVisits)
+			}
+		}
+		if !reflect.DeepEqual(keys, AnnualOutreachTotals) {
+			t.Errorf("%s: outreach totals = %v, want %v", tt.name, keys, AnnualOutreachTotals)
+		}
+
+		if !reflect.DeepEqual(annual.Offerings, tt.wantOfferings) {
+			t.Errorf("%s: offerings = %v, want %v", tt.name, annual.Offerings, tt.wantOfferings)
+		}
+	}
+}
+
+func floatPtr(v float64) *float64 {
+	return &v
+}
+
+// formatMonthly writes the monthly averages with "-" for months without a
+// report, so failures show values rather than pointers
+func formatMonthly(monthly []*float64) []string {
+	values := make([]string, len(monthly))
+	for i, v := range monthly {
+		values[i] = "-"
+		if v != nil {
+			values[i] = strconv.FormatFloat(*v, 'f', -1, 64)
+		}
+	}
+	return values
+}
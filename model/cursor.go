@@ -0,0 +1,140 @@
+package model
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ErrInvalidCursor is returned for a cursor this server did not write
+var ErrInvalidCursor = errors.New("invalid cursor")
+
+// ReportCursor marks a report in a sorted list, so the next page can start
+// right after it (or, going back, end right before it) however many reports
+// were added or removed meanwhile. Clients treat it as an opaque string.
+type ReportCursor struct {
+	// Sort is the sort the cursor was made for, as in "-month_of"
+	Sort string `json:"s"`
+	// Values holds the report's value of every key of KeysetSort(Sort)
+	Values []string `json:"v"`
+	// Before selects the reports before the marked one instead of after
+	Before bool `json:"b,omitempty"`
+
+	// Fields and Params are Sort and Values parsed
+	Fields []SortField   `json:"-"`
+	Params []interface{} `json:"-"`
+}
+
+// KeysetSort completes a sort with the id, so that every report has its own
+// position; keys after the id are never compared and are dropped
+func KeysetSort(sort []SortField) []SortField {
+	for i, field := range sort {
+		if field.Key == "id" {
+			return sort[:i+1]
+		}
+	}
+	return append(append([]SortField{}, sort...), SortField{Key: "id"})
+}
+
+// FormatReportSort writes a sort the way ParseReportSort reads it
+func FormatReportSort(sort []SortField) string {
+	keys := make([]string, len(sort))
+	for i, field := range sort {
+		keys[i] = field.Key
+		if field.Desc {
+			keys[i] = "-" + field.Key
+		}
+	}
+	return strings.Join(keys, ",")
+}
+
+// NewReportCursor marks the report in a list with the given sort
+func NewReportCursor(sort []SortField, report *Report, before bool) *ReportCursor {
+	cursor := &ReportCursor{Sort: FormatReportSort(sort), Before: before}
+	for _, field := range KeysetSort(sort) {
+		cursor.Values = append(cursor.Values, reportSortValue(report, field.Key))
+	}
+	return cursor
+}
+
+// Encode writes the cursor as a URL-safe string
+func (c *ReportCursor) Encode() string {
+	body, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(body)
+}
+
+// ParseReportCursor reads a cursor written by Encode
+func ParseReportCursor(value string) (*ReportCursor, error) {
+	body, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+
+	var cursor ReportCursor
+	if err := json.Unmarshal(body, &cursor); err != nil {
+		return nil, ErrInvalidCursor
+	}
+
+	cursor.Fields, err = ParseReportSort(cursor.Sort)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+
+	keys := KeysetSort(cursor.Fields)
+	if len(cursor.Values) != len(keys) {
+		return nil, ErrInvalidCursor
+	}
+	for i, field := range keys {
+		param, err := reportSortParam(field.Key, cursor.Values[i])
+		if err != nil {
+			return nil, ErrInvalidCursor
+		}
+		cursor.Params = append(cursor.Params, param)
+	}
+
+	return &cursor, nil
+}
+
+// reportSortValue writes the report's value of a sort key
+func reportSortValue(report *Report, key string) string {
+	switch key {
+	case "id":
+		return strconv.Itoa(report.Id)
+	case "month_of":
+		return report.MonthOf.String()
+	case "worker_name":
+		return report.WorkerName
+	case "area_of_assignment":
+		return report.AreaOfAssignment
+	case "name_of_church":
+		return report.NameOfChurch
+	case "status":
+		return string(report.Status)
+	case "created_at":
+		return report.CreatedAt.Format(time.RFC3339Nano)
+	case "updated_at":
+		return report.UpdatedAt.Format(time.RFC3339Nano)
+	}
+
+	activity, _ := FindActivity(strings.TrimSuffix(key, "_average"))
+	return strconv.FormatFloat(CalculateAverage(activity.Values(report)), 'f', -1, 64)
+}
+
+// reportSortParam reads a value written by reportSortValue as the query
+// parameter it is compared with
+func reportSortParam(key, value string) (interface{}, error) {
+	switch key {
+	case "id":
+		return strconv.Atoi(value)
+	case "month_of":
+		return ParsePeriod(value)
+	case "worker_name", "area_of_assignment", "name_of_church", "status":
+		return value, nil
+	case "created_at", "updated_at":
+		return time.Parse(time.RFC3339Nano, value)
+	}
+	return strconv.ParseFloat(value, 64)
+}
@@ -0,0 +1,92 @@
+package model
+
+import (
+	"encoding/base64"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestReportCursorRoundTrip(t *testing.T) {
+	createdAt := time.Date(2024, time.April, 2, 9, 30, 15, 123456789, time.UTC)
+	report := &Report{
+		Id:             42,
+		MonthOf:        NewPeriod(2024, time.March),
+		WorkerName:     "Juan, \"JC\" Cruz",
+		Status:         StatusSubmitted,
+		WorshipService: []int{40, 45},
+		CreatedAt:      createdAt,
+	}
+
+	tests := []struct {
+		sort       string
+		before     bool
+		wantParams []interface{}
+	}{
+		{sort: "", wantParams: []interface{}{42}},
+		{sort: "-month_of,worker_name", wantParams: []interface{}{NewPeriod(2024, time.March), "Juan, \"JC\" Cruz", 42}},
+		{sort: "status", before: true, wantParams: []interface{}{"submitted", 42}},
+		{sort: "-created_at", wantParams: []interface{}{createdAt, 42}},
+		// Averages are rounded as in the list, 42.5 to 43
+		{sort: "worship_service_average", wantParams: []interface{}{43.0, 42}},
+		// Keys after the id are never compared, so they are not kept
+		{sort: "-id,month_of", wantParams: []interface{}{42}},
+	}
+
+	for _, tt := range tests {
+		sort, err := ParseReportSort(tt.sort)
+		if err != nil {
+			t.Fatalf("ParseReportSort(%q) error = %v", tt.sort, err)
+		}
+
+		encoded := NewReportCursor(sort, report, tt.before).Encode()
+		cursor, err := ParseReportCursor(encoded)
+		if err != nil {
+			t.Errorf("sort %q: ParseReportCursor() error = %v", tt.sort, err)
+			continue
+		}
+
+		if cursor.Sort != FormatReportSort(sort) || cursor.Before != tt.before {
+			t.Errorf("sort %q: cursor sort, before = %q, %v", tt.sort, cursor.Sort, cursor.Before)
+		}
+		if !reflect.DeepEqual(cursor.Fields, sort) {
+			t.Errorf("sort %q: cursor fields = %v, want %v", tt.sort, cursor.Fields, sort)
+		}
+		if !reflect.DeepEqual(cursor.Params, tt.wantParams) {
+			t.Errorf("sort %q: cursor params = %#v, want %#v", tt.sort, cursor.Params, tt.wantParams)
+		}
+	}
+}
+
+func TestParseReportCursorTampered(t *testing.T) {
+	encode := func(body string) string {
+		return base64.RawURLEncoding.EncodeToString([]byte(body))
+	}
+
+	tests := []struct {
+		name  string
+		value string
+	}{
+		{name: "empty", value: ""},
+		{name: "not base64", value: "not a cursor!"},
+		{name: "padded base64", value: base64.URLEncoding.EncodeToString([]byte(`{"s":"","v":["12"]}`))},
+		{name: "not JSON", value: encode("month_of=2024-03")},
+		{name: "wrong value type", value: encode(`{"s":"","v":[1]}`)},
+		{name: "unknown sort key", value: encode(`{"s":"password","v":["x","1"]}`)},
+		{name: "repeated sort key", value: encode(`{"s":"status,-status","v":["a","b","1"]}`)},
+		{name: "too few values", value: encode(`{"s":"-month_of","v":["1"]}`)},
+		{name: "too many values", value: encode(`{"s":"","v":["1","2"]}`)},
+		{name: "bad id", value: encode(`{"s":"","v":["1 OR 1=1"]}`)},
+		{name: "bad month", value: encode(`{"s":"month_of","v":["someday","1"]}`)},
+		{name: "bad time", value: encode(`{"s":"created_at","v":["yesterday","1"]}`)},
+		{name: "bad average", value: encode(`{"s":"outreach_average","v":["many","1"]}`)},
+	}
+
+	for _, tt := range tests {
+		cursor, err := ParseReportCursor(tt.value)
+		if !errors.Is(err, ErrInvalidCursor) {
+			t.Errorf("%s: ParseReportCursor(%q) = %+v, %v, want ErrInvalidCursor", tt.name, tt.value, cursor, err)
+		}
+	}
+}
@@ -0,0 +1,15 @@
+package model
+
+// CellError points at a spreadsheet cell that could not be read
+type CellError struct {
+	Cell    string `json:"cell,omitempty"`
+	Field   string `json:"field"`
+	Message string `json:"message"`
+}
+
+// FieldChange is one line of an import preview
+type FieldChange struct {
+	Field string      `json:"field"`
+	Old   interface{} `json:"old"`
+	New   interface{} `json:"new"`
+}
@@ -0,0 +1,138 @@
+package model
+
+import (
+	"encoding/json"
+	"errors"
+	"strconv"
+	"strings"
+)
+
+// DefaultCurrency is the currency of reports that do not name one
+const DefaultCurrency = "PHP"
+
+// Money is an exact amount in hundredths of the currency unit, so that
+// offerings add up to the centavo. It is written in JSON as a decimal
+// string such as "1500.25".
+type Money int64
+
+var errInvalidMoney = errors.New("must be an amount with at most 2 decimal places")
+
+// ParseMoney reads an amount such as "1500", "1,500.5" or "1500.25"
+func ParseMoney(value string) (Money, error) {
+	value = strings.ReplaceAll(strings.TrimSpace(value), ",", "")
+
+	negative := strings.HasPrefix(value, "-")
+	value = strings.TrimPrefix(value, "-")
+
+	units, cents, hasCents := strings.Cut(value, ".")
+	if units == "" && cents == "" || len(units) > 13 || len(cents) > 2 || hasCents && cents == "" {
+		return 0, errInvalidMoney
+	}
+	for len(cents) < 2 {
+		cents += "0"
+	}
+	if units == "" {
+		units = "0"
+	}
+
+	for _, r := range units + cents {
+		if r < '0' || r > '9' {
+			return 0, errInvalidMoney
+		}
+	}
+
+	amount, err := strconv.ParseInt(units+cents, 10, 64)
+	if err != nil {
+		return 0, errInvalidMoney
+	}
+	if negative {
+		amount = -amount
+	}
+
+	return Money(amount), nil
+}
+
+// String formats the amount as "1500.25"
+func (m Money) String() string {
+	sign := ""
+	if m < 0 {
+		sign = "-"
+		m = -m
+	}
+	return sign + strconv.FormatInt(int64(m/100), 10) + "." + leftPad(strconv.FormatInt(int64(m%100), 10), 2)
+}
+
+// Grouped formats the amount with thousands separators, as "1,500.25"
+func (m Money) Grouped() string {
+	text := m.String()
+	sign := ""
+	if strings.HasPrefix(text, "-") {
+		sign, text = "-", text[1:]
+	}
+
+	units, cents, _ := strings.Cut(text, ".")
+	for i := len(units) - 3; i > 0; i -= 3 {
+		units = units[:i] + "," + units[i:]
+	}
+	return sign + units + "." + cents
+}
+
+// Float64 is for spreadsheets, which store numbers as floats anyway
+func (m Money) Float64() float64 {
+	return float64(m) / 100
+}
+
+func (m Money) MarshalJSON() ([]byte, error) {
+	return json.Marshal(m.String())
+}
+
+// UnmarshalJSON accepts "1500.25" or 1500.25; numbers are read from their
+// literal text, never through a float
+func (m *Money) UnmarshalJSON(data []byte) error {
+	text := string(data)
+	if text == "null" {
+		return nil
+	}
+
+	if strings.HasPrefix(text, `"`) {
+		if err := json.Unmarshal(data, &text); err != nil {
+			return err
+		}
+	}
+
+	amount, err := ParseMoney(text)
+	if err != nil {
+		return err
+	}
+	*m = amount
+	return nil
+}
+
+// SumMoney adds up the amounts
+func SumMoney(values []Money) Money {
+	var total Money
+	for _, v := range values {
+		total += v
+	}
+	return total
+}
+
+// ValidCurrency reports whether code looks like an ISO 4217 code
+func ValidCurrency(code string) bool {
+	if len(code) != 3 {
+		return false
+	}
+	for _, r := range code {
+		if r < 'A' || r > 'Z' {
+			return false
+		}
+	}
+	return true
+}
+
+func leftPad(value string, width int) string {
+	for len(value) < width {
+		value = "0" + value
+	}
+	return value
+}
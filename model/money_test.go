@@ -0,0 +1,119 @@
+package model
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestParseMoney(t *testing.T) {
+	tests := []struct {
+		value   string
+		want    Money
+		wantErr bool
+	}{
+		{value: "1500", want: 150000},
+		{value: "1500.25", want: 150025},
+		{value: "1500.5", want: 150050},
+		{value: "1,500.5", want: 150050},
+		{value: " 12 ", want: 1200},
+		{value: ".5", want: 50},
+		{value: "0.05", want: 5},
+		{value: "0", want: 0},
+		{value: "-0.05", want: -5},
+		{value: "-1,500.25", want: -150025},
+		{value: "9999999999999.99", want: 999999999999999},
+		{value: "", wantErr: true},
+		{value: "-", wantErr: true},
+		{value: ".", wantErr: true},
+		{value: "1.", wantErr: true},
+		{value: "1.234", wantErr: true},
+		{value: "1.2.3", wantErr: true},
+		{value: "--1", wantErr: true},
+		{value: "+1", wantErr: true},
+		{value: "1e3", wantErr: true},
+		{value: "abc", wantErr: true},
+		{value: "12345678901234", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		got, err := ParseMoney(tt.value)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ParseMoney(%q) error = %v, wantErr %v", tt.value, err, tt.wantErr)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("ParseMoney(%q) = %d, want %d", tt.value, got, tt.want)
+		}
+	}
+}
+
+func TestMoneyString(t *testing.T) {
+	tests := []struct {
+		amount      Money
+		want        string
+		wantGrouped string
+	}{
+		{0, "0.00", "0.00"},
+		{5, "0.05", "0.05"},
+		{50, "0.50", "0.50"},
+		{100, "1.00", "1.00"},
+		{150025, "1500.25", "1,500.25"},
+		{-5, "-0.05", "-0.05"},
+		{-150025, "-1500.25", "-1,500.25"},
+		{123456789, "1234567.89", "1,234,567.89"},
+		{-100000000, "-1000000.00", "-1,000,000.00"},
+	}
+
+	for _, tt := range tests {
+		if got := tt.amount.String(); got != tt.want {
+			t.Errorf("Money(%d).String() = %q, want %q", tt.amount, got, tt.want)
+		}
+		if got := tt.amount.Grouped(); got != tt.wantGrouped {
+			t.Errorf("Money(%d).Grouped() = %q, want %q", tt.amount, got, tt.wantGrouped)
+		}
+
+		// Every amount reads back as itself
+		parsed, err := ParseMoney(tt.amount.String())
+		if err != nil || parsed != tt.amount {
+			t.Errorf("ParseMoney(%q) = %d, %v, want %d", tt.amount.String(), parsed, err, tt.amount)
+		}
+	}
+}
+
+func TestMoneyJSON(t *testing.T) {
+	tests := []struct {
+		json    string
+		want    Money
+		wantErr bool
+	}{
+		{json: `"1500.25"`, want: 150025},
+		{json: `1500.25`, want: 150025},
+		{json: `0.1`, want: 10},
+		{json: `-3`, want: -300},
+		{json: `null`, want: 0},
+		{json: `0.001`, wantErr: true},
+		{json: `1e2`, wantErr: true},
+		{json: `"ten"`, wantErr: true},
+		{json: `true`, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		var got Money
+		err := json.Unmarshal([]byte(tt.json), &got)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("Unmarshal(%s) error = %v, wantErr %v", tt.json, err, tt.wantErr)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("Unmarshal(%s) = %d, want %d", tt.json, got, tt.want)
+		}
+	}
+
+	body, err := json.Marshal([]Money{150025, -5})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := `["1500.25","-0.05"]`; string(body) != want {
+		t.Errorf("Marshal = %s, want %s", body, want)
+	}
+}
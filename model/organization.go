@@ -0,0 +1,92 @@
+package model
+
+import "time"
+
+// OrgLevel is a tier of the church hierarchy: regions contain districts,
+// districts contain areas and areas contain churches
+type OrgLevel string
+
+const (
+	LevelRegion   OrgLevel = "region"
+	LevelDistrict OrgLevel = "district"
+	LevelArea     OrgLevel = "area"
+	LevelChurch   OrgLevel = "church"
+)
+
+// OrgLevels lists the levels from the top of the hierarchy down
+var OrgLevels = []OrgLevel{LevelRegion, LevelDistrict, LevelArea, LevelChurch}
+
+func (l OrgLevel) Valid() bool {
+	for _, level := range OrgLevels {
+		if l == level {
+			return true
+		}
+	}
+	return false
+}
+
+// Parent returns the level above l, or "" for regions
+func (l OrgLevel) Parent() OrgLevel {
+	for i, level := range OrgLevels {
+		if level == l && i > 0 {
+			return OrgLevels[i-1]
+		}
+	}
+	return ""
+}
+
+// OrgUnit is a region, district, area or church
+type OrgUnit struct {
+	Id         int       `json:"id"`
+	Level      OrgLevel  `json:"level"`
+	ParentId   int       `json:"parent_id,omitempty"`
+	ParentName string    `json:"parent_name,omitempty"`
+	Name       string    `json:"name"`
+	CreatedAt  time.Time `json:"created_at"`
+	UpdatedAt  time.Time `json:"updated_at"`
+}
+
+type SearchOrgUnitQuery struct {
+	Level    OrgLevel `form:"-"`
+	ParentId int      `form:"parent_id"`
+	Name     string   `form:"name"`
+}
+
+// OfferingsMetric is the rollup metric for tithes and offerings. Amounts in
+// different currencies do not add up, so it is totalled per currency.
+const OfferingsMetric = "tithes_and_offerings"
+
+// ValidRollupMetric reports whether a rollup can aggregate the metric
+func ValidRollupMetric(metric string) bool {
+	if metric == OfferingsMetric {
+		return true
+	}
+	_, ok := FindActivity(metric)
+	return ok
+}
+
+// RollupQuery selects the reports and the metric to aggregate by level
+type RollupQuery struct {
+	Metric string             `form:"metric"`
+	Level  OrgLevel           `form:"level"`
+	Filter *SearchReportQuery `form:"-"`
+}
+
+// RollupRow aggregates one activity over the reports of a hierarchy unit.
+// For the offerings metric only Offerings is filled in.
+type RollupRow struct {
+	Id             int              `json:"id"`
+	Name           string           `json:"name"`
+	ReportCount    int              `json:"report_count"`
+	Total          float64          `json:"total"`
+	Average        float64          `json:"average"`
+	SumOfAverages  float64          `json:"sum_of_averages"`
+	AveragePerWeek float64          `json:"average_per_week"`
+	Offerings      map[string]Money `json:"tithes_and_offerings_total,omitempty"`
+}
+
+type RollupResult struct {
+	Metric string       `json:"metric"`
+	Level  OrgLevel     `json:"level"`
+	Rows   []*RollupRow `json:"rows"`
+}
@@ -0,0 +1,210 @@
+package model
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Period is a calendar month. It is stored as the first day of the month in
+// a DATE column and travels over JSON as "YYYY-MM".
+type Period struct {
+	Year  int
+	Month time.Month
+}
+
+var (
+	yearMonthPattern = regexp.MustCompile(`^(\d{4})[-/](\d{1,2})(?:[-/]\d{1,2})?$`)
+	monthYearPattern = regexp.MustCompile(`^(\d{1,2})[-/](\d{4})$`)
+	namedPattern     = regexp.MustCompile(`^([a-z]+) (\d{4})$`)
+	yearNamedPattern = regexp.MustCompile(`^(\d{4}) ([a-z]+)$`)
+)
+
+func NewPeriod(year int, month time.Month) Period {
+	return Period{Year: year, Month: month}
+}
+
+// PeriodOf returns the period containing t
+func PeriodOf(t time.Time) Period {
+	return Period{Year: t.Year(), Month: t.Month()}
+}
+
+// ParsePeriod accepts the spellings workers commonly type: "2024-01",
+// "2024/1", "01/2024", "Jan 2024", "January, 2024", "Sept. 2024" and
+// "2024 January". A full date such as "2024-01-15" yields its month.
+func ParsePeriod(value string) (Period, error) {
+	normalized := strings.ToLower(strings.TrimSpace(value))
+	normalized = strings.NewReplacer(",", " ", ".", " ").Replace(normalized)
+	normalized = strings.Join(strings.Fields(normalized), " ")
+
+	if normalized == "" {
+		return Period{}, fmt.Errorf("month must not be empty")
+	}
+
+	var year, month int
+	var monthName string
+
+	if m := yearMonthPattern.FindStringSubmatch(normalized); m != nil {
+		year, _ = strconv.Atoi(m[1])
+		month, _ = strconv.Atoi(m[2])
+	} else if m := monthYearPattern.FindStringSubmatch(normalized); m != nil {
+		month, _ = strconv.Atoi(m[1])
+		year, _ = strconv.Atoi(m[2])
+	} else if m := namedPattern.FindStringSubmatch(normalized); m != nil {
+		monthName = m[1]
+		year, _ = strconv.Atoi(m[2])
+	} else if m := yearNamedPattern.FindStringSubmatch(normalized); m != nil {
+		year, _ = strconv.Atoi(m[1])
+		monthName = m[2]
+	} else {
+		return Period{}, fmt.Errorf("unrecognized month %q, use YYYY-MM or e.g. January 2024", value)
+	}
+
+	if monthName != "" {
+		month = monthFromName(monthName)
+		if month == 0 {
+			return Period{}, fmt.Errorf("unrecognized month name %q", value)
+		}
+	}
+
+	if month < 1 || month > 12 {
+		return Period{}, fmt.Errorf("month out of range in %q", value)
+	}
+
+	if year < 1900 || year > 2999 {
+		return Period{}, fmt.Errorf("year out of range in %q", value)
+	}
+
+	return Period{Year: year, Month: time.Month(month)}, nil
+}
+
+// monthFromName matches full names and abbreviations of at least three
+// letters, e.g. "jan", "sept" and "september"
+func monthFromName(name string) int {
+	if len(name) < 3 {
+		return 0
+	}
+	for m := time.January; m <= time.December; m++ {
+		if strings.HasPrefix(strings.ToLower(m.String()), name) {
+			return int(m)
+		}
+	}
+	return 0
+}
+
+func (p Period) IsZero() bool {
+	return p.Year == 0 && p.Month == 0
+}
+
+// String formats the period as "YYYY-MM"
+func (p Period) String() string {
+	if p.IsZero() {
+		return ""
+	}
+	return fmt.Sprintf("%04d-%02d", p.Year, int(p.Month))
+}
+
+// Label formats the period for people, e.g. "January 2024"
+func (p Period) Label() string {
+	if p.IsZero() {
+		return ""
+	}
+	return fmt.Sprintf("%s %d", p.Month, p.Year)
+}
+
+// Time returns midnight UTC on the first day of the period
+func (p Period) Time() time.Time {
+	return time.Date(p.Year, p.Month, 1, 0, 0, 0, 0, time.UTC)
+}
+
+// AddMonths returns the period n months later (or earlier when negative)
+func (p Period) AddMonths(n int) Period {
+	return PeriodOf(p.Time().AddDate(0, n, 0))
+}
+
+// MonthsUntil returns the number of months from p to other
+func (p Period) MonthsUntil(other Period) int {
+	return (other.Year-p.Year)*12 + int(other.Month-p.Month)
+}
+
+// Weeks returns the number of Sundays in the period, which is how many
+// weekly values a report for the month can hold (4 or 5)
+func (p Period) Weeks() int {
+	if p.IsZero() {
+		return 5
+	}
+
+	first := p.Time()
+	untilSunday := (7 - int(first.Weekday())) % 7
+	days := first.AddDate(0, 1, -1).Day()
+	return (days-untilSunday-1)/7 + 1
+}
+
+func (p Period) Before(other Period) bool {
+	return p.Time().Before(other.Time())
+}
+
+func (p Period) MarshalJSON() ([]byte, error) {
+	if p.IsZero() {
+		return []byte("null"), nil
+	}
+	return json.Marshal(p.String())
+}
+
+func (p *Period) UnmarshalJSON(data []byte) error {
+	var value *string
+	if err := json.Unmarshal(data, &value); err != nil {
+		return fmt.Errorf("month must be a string such as \"2024-01\"")
+	}
+
+	if value == nil || strings.TrimSpace(*value) == "" {
+		*p = Period{}
+		return nil
+	}
+
+	parsed, err := ParsePeriod(*value)
+	if err != nil {
+		return err
+	}
+
+	*p = parsed
+	return nil
+}
+
+// Scan implements sql.Scanner
+func (p *Period) Scan(src interface{}) error {
+	switch v := src.(type) {
+	case nil:
+		*p = Period{}
+	case time.Time:
+		*p = PeriodOf(v)
+	case []byte:
+		return p.scanString(string(v))
+	case string:
+		return p.scanString(v)
+	default:
+		return fmt.Errorf("cannot scan %T into Period", src)
+	}
+	return nil
+}
+
+func (p *Period) scanString(value string) error {
+	parsed, err := ParsePeriod(value)
+	if err != nil {
+		return err
+	}
+	*p = parsed
+	return nil
+}
+
+// Value implements driver.Valuer
+func (p Period) Value() (driver.Value, error) {
+	if p.IsZero() {
+		return nil, nil
+	}
+	return p.Time(), nil
+}
@@ -0,0 +1,89 @@
+package model
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParsePeriod(t *testing.T) {
+	tests := []struct {
+		value   string
+		want    Period
+		wantErr bool
+	}{
+		{value: "2024-01", want: NewPeriod(2024, time.January)},
+		{value: "2024/1", want: NewPeriod(2024, time.January)},
+		{value: "01/2024", want: NewPeriod(2024, time.January)},
+		{value: "1-2024", want: NewPeriod(2024, time.January)},
+		{value: "2024-01-15", want: NewPeriod(2024, time.January)},
+		{value: "Jan 2024", want: NewPeriod(2024, time.January)},
+		{value: "January, 2024", want: NewPeriod(2024, time.January)},
+		{value: "  january   2024 ", want: NewPeriod(2024, time.January)},
+		{value: "Sept. 2024", want: NewPeriod(2024, time.September)},
+		{value: "2024 December", want: NewPeriod(2024, time.December)},
+		{value: "", wantErr: true},
+		{value: "   ", wantErr: true},
+		{value: "2024-13", wantErr: true},
+		{value: "00/2024", wantErr: true},
+		{value: "Ja 2024", wantErr: true},
+		{value: "Janx 2024", wantErr: true},
+		{value: "1899-12", wantErr: true},
+		{value: "3000-01", wantErr: true},
+		{value: "next month", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		got, err := ParsePeriod(tt.value)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ParsePeriod(%q) error = %v, wantErr %v", tt.value, err, tt.wantErr)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("ParsePeriod(%q) = %v, want %v", tt.value, got, tt.want)
+		}
+	}
+}
+
+func TestPeriodWeeks(t *testing.T) {
+	tests := []struct {
+		period Period
+		want   int
+	}{
+		// The 1st is a Monday: Sundays on the 7th, 14th, 21st and 28th
+		{NewPeriod(2024, time.January), 4},
+		// The 1st is a Friday and the month has 31 days
+		{NewPeriod(2024, time.March), 5},
+		// The 1st is a Sunday and the month has 30 days
+		{NewPeriod(2024, time.September), 5},
+		// A February starting on a Sunday
+		{NewPeriod(2026, time.February), 4},
+		// A leap February starting on a Thursday
+		{NewPeriod(2024, time.February), 4},
+		{Period{}, 5},
+	}
+
+	for _, tt := range tests {
+		if got := tt.period.Weeks(); got != tt.want {
+			t.Errorf("%v.Weeks() = %d, want %d", tt.period, got, tt.want)
+		}
+	}
+}
+
+func TestPeriodMonthsUntil(t *testing.T) {
+	tests := []struct {
+		from, to Period
+		want     int
+	}{
+		{NewPeriod(2024, time.January), NewPeriod(2024, time.January), 0},
+		{NewPeriod(2024, time.January), NewPeriod(2024, time.March), 2},
+		{NewPeriod(2023, time.November), NewPeriod(2024, time.February), 3},
+		{NewPeriod(2024, time.May), NewPeriod(2024, time.January), -4},
+		{NewPeriod(2022, time.December), NewPeriod(2024, time.December), 24},
+	}
+
+	for _, tt := range tests {
+		if got := tt.from.MonthsUntil(tt.to); got != tt.want {
+			t.Errorf("%v.MonthsUntil(%v) = %d, want %d", tt.from, tt.to, got, tt.want)
+		}
+	}
+}
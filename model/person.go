@@ -0,0 +1,156 @@
+package model
+
+import (
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+type PersonCategory string
+
+const (
+	PersonNewlyContacted PersonCategory = "newly_contacted"
+	PersonFollowedUp     PersonCategory = "followed_up"
+	PersonLedToChrist    PersonCategory = "led_to_christ"
+	PersonHomeVisited    PersonCategory = "home_visited"
+)
+
+// PersonCategories lists the categories in the order they are shown
+var PersonCategories = []PersonCategory{PersonNewlyContacted, PersonFollowedUp, PersonLedToChrist, PersonHomeVisited}
+
+func (c PersonCategory) Valid() bool {
+	for _, category := range PersonCategories {
+		if c == category {
+			return true
+		}
+	}
+	return false
+}
+
+// Label formats the category for people, e.g. "Led To Christ"
+func (c PersonCategory) Label() string {
+	words := strings.Split(string(c), "_")
+	for i, word := range words {
+		if word != "" {
+			words[i] = strings.ToUpper(word[:1]) + word[1:]
+		}
+	}
+	return strings.Join(words, " ")
+}
+
+// ReportPerson is someone the worker reached during the month. Date is
+// "YYYY-MM-DD" and must fall within the month of the report.
+type ReportPerson struct {
+	Name     string         `json:"name"`
+	Category PersonCategory `json:"category"`
+	Contact  string         `json:"contact,omitempty"`
+	Date     string         `json:"date,omitempty"`
+}
+
+// PersonOccurrence is one report that mentions a person, for following
+// someone across months
+type PersonOccurrence struct {
+	ReportId     int           `json:"report_id"`
+	MonthOf      Period        `json:"month_of"`
+	WorkerId     int           `json:"worker_id"`
+	WorkerName   string        `json:"worker_name"`
+	NameOfChurch string        `json:"name_of_church"`
+	Person       *ReportPerson `json:"person"`
+}
+
+// Upper bounds on the people of one report
+const (
+	MaxPeople        = 500
+	MaxContactLength = 200
+)
+
+// CountPeople counts the people of each category, from which the monthly
+// totals of the person_* and home_visited activities can be checked.
+// People without a category are not counted.
+func CountPeople(people []*ReportPerson) map[PersonCategory]int {
+	counts := map[PersonCategory]int{}
+	for _, category := range PersonCategories {
+		counts[category] = 0
+	}
+	for _, person := range people {
+		if person != nil && person.Category.Valid() {
+			counts[person.Category]++
+		}
+	}
+	return counts
+}
+
+// validatePeople checks every person entry of the report
+func (r *Report) validatePeople(v *ValidationError) {
+	if len(r.People) > MaxPeople {
+		v.Add("people", CodeTooLarge, fmt.Sprintf("must not list more than %d people", MaxPeople))
+	}
+
+	for i, person := range r.People {
+		at := fmt.Sprintf("person %d", i+1)
+		if person == nil {
+			v.Add("people", CodeRequired, at+" must not be null")
+			continue
+		}
+
+		switch {
+		case strings.TrimSpace(person.Name) == "":
+			v.Add("people", CodeRequired, at+": name must not be empty")
+		case utf8.RuneCountInString(person.Name) > MaxNameLength:
+			v.Add("people", CodeTooLong, fmt.Sprintf("%s: name must not be longer than %d characters", at, MaxNameLength))
+		}
+
+		// Names recorded before people had categories have none; a report
+		// cannot be submitted until they are filled in
+		if person.Category != "" && !person.Category.Valid() {
+			v.Add("people", CodeInvalid, fmt.Sprintf("%s: category must be one of %s", at, joinCategories()))
+		}
+
+		if utf8.RuneCountInString(person.Contact) > MaxContactLength {
+			v.Add("people", CodeTooLong, fmt.Sprintf("%s: contact must not be longer than %d characters", at, MaxContactLength))
+		}
+
+		if person.Date != "" {
+			date, err := time.Parse("2006-01-02", person.Date)
+			switch {
+			case err != nil:
+				v.Add("people", CodeInvalid, at+": date must be YYYY-MM-DD")
+			case !r.MonthOf.IsZero() && PeriodOf(date) != r.MonthOf:
+				v.Add("people", CodeInvalid, fmt.Sprintf("%s: date must be in %s", at, r.MonthOf.Label()))
+			}
+		}
+	}
+}
+
+// personActivities pairs each person_* activity with the category of the
+// people it counts
+var personActivities = []struct {
+	key      string
+	category PersonCategory
+}{
+	{"person_newly_contacted", PersonNewlyContacted},
+	{"person_followed_up", PersonFollowedUp},
+	{"person_led_to_christ", PersonLedToChrist},
+}
+
+// validatePeopleCounts checks that the weeks of every person_* activity add
+// up to the people listed under its category, so the figures and the names
+// of a submitted report tell the same story
+func (r *Report) validatePeopleCounts(v *ValidationError) {
+	counts := CountPeople(r.People)
+	for _, pair := range personActivities {
+		activity, _ := FindActivity(pair.key)
+		if total := Sum(activity.Values(r)); total != counts[pair.category] {
+			v.Add(pair.key, CodeMismatch, fmt.Sprintf("weeks add up to %d but %d people are listed as %s", total, counts[pair.category], pair.category.Label()))
+		}
+	}
+}
+
+func joinCategories() string {
+	names := make([]string, len(PersonCategories))
+	for i, category := range PersonCategories {
+		names[i] = string(category)
+	}
+	return strings.Join(names, ", ")
+}
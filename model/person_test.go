@@ -0,0 +1,84 @@
+package model
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestValidateForSubmitPeopleCounts(t *testing.T) {
+	people := []*ReportPerson{
+		{Name: "Juan", Category: PersonNewlyContacted},
+		{Name: "Maria", Category: PersonNewlyContacted},
+		{Name: "Pedro", Category: PersonLedToChrist},
+		{Name: "Ana", Category: PersonHomeVisited},
+	}
+
+	tests := []struct {
+		name           string
+		newlyContacted []int
+		followedUp     []int
+		ledToChrist    []int
+		people         []*ReportPerson
+		wantFields     []string
+	}{
+		{
+			name:           "counts match the people",
+			newlyContacted: []int{1, 0, 1},
+			ledToChrist:    []int{0, 0, 0, 1},
+			people:         people,
+		},
+		{name: "no counts and no people"},
+		{
+			name:           "more counted than listed",
+			newlyContacted: []int{2, 1},
+			ledToChrist:    []int{1},
+			people:         people,
+			wantFields:     []string{"person_newly_contacted"},
+		},
+		{
+			name:       "counted without any people",
+			followedUp: []int{1},
+			wantFields: []string{"person_followed_up"},
+		},
+		{
+			name:       "people without counts",
+			people:     people,
+			wantFields: []string{"person_newly_contacted", "person_led_to_christ"},
+		},
+	}
+
+	for _, tt := range tests {
+		report := &Report{
+			MonthOf:                         NewPeriod(2024, time.March),
+			WorshipService:                  []int{40},
+			SundaySchool:                    []int{20},
+			PersonNewlyContacted:            tt.newlyContacted,
+			PersonFollowedUp:                tt.followedUp,
+			PersonLedToChrist:               tt.ledToChrist,
+			People:                          tt.people,
+			NarrativeReport:                 "A good month",
+			ChallengesAndProblemEncountered: "Rain",
+			PrayerRequest:                   "Dry weather",
+		}
+
+		var fields []string
+		var v *ValidationError
+		if err := report.ValidateForSubmit(); errors.As(err, &v) {
+			for _, field := range v.Fields {
+				if field.Code != CodeMismatch {
+					t.Errorf("%s: unexpected error %s: %s", tt.name, field.Field, field.Message)
+					continue
+				}
+				fields = append(fields, field.Field)
+			}
+		} else if err != nil {
+			t.Fatalf("%s: ValidateForSubmit() error = %v", tt.name, err)
+		}
+
+		if !reflect.DeepEqual(fields, tt.wantFields) {
+			t.Errorf("%s: mismatched fields = %v, want %v", tt.name, fields, tt.wantFields)
+		}
+	}
+}
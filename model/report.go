@@ -6,73 +6,129 @@ import (
 )
 
 type Report struct {
-	Id                              int       `json:"id"`
-	MonthOf                         string    `json:"month_of"`
-	WorkerName                      string    `json:"worker_name"`
-	AreaOfAssignment                string    `json:"area_of_assignment"`
-	NameOfChurch                    string    `json:"name_of_church"`
-	WorshipService                  []int     `json:"worship_service,omitempty"`
-	SundaySchool                    []int     `json:"sunday_school,omitempty"`
-	PrayerMeetings                  []int     `json:"prayer_meetings,omitempty"`
-	BibleStudies                    []int     `json:"bible_studies,omitempty"`
-	MensFellowships                 []int     `json:"mens_fellowships,omitempty"`
-	WomensFellowships               []int     `json:"womens_fellowships,omitempty"`
-	YouthFellowships                []int     `json:"youth_fellowships,omitempty"`
-	ChildFellowships                []int     `json:"child_fellowships,omitempty"`
-	Outreach                        []int     `json:"outreach,omitempty"`
-	TrainingOrSeminars              []int     `json:"training_or_seminars,omitempty"`
-	LeadershipConferences           []int     `json:"leadership_conferences,omitempty"`
-	LeadershipTraining              []int     `json:"leadership_training,omitempty"`
-	Others                          []int     `json:"others,omitempty"`
-	FamilyDays                      []int     `json:"family_days,omitempty"`
-	TithesAndOfferings              []int     `json:"tithes_and_offerings,omitempty"`
-	WorshipServiceAvg               float64   `json:"worship_service_average,omitempty"`
-	SundaySchoolAvg                 float64   `json:"sunday_school_average,omitempty"`
-	PrayerMeetingsAvg               float64   `json:"prayer_meetings_average,omitempty"`
-	BibleStudiesAvg                 float64   `json:"bible_studies_average,omitempty"`
-	MensFellowshipsAvg              float64   `json:"mens_fellowships_average,omitempty"`
-	WomensFellowshipsAvg            float64   `json:"womens_fellowships_average,omitempty"`
-	YouthFellowshipsAvg             float64   `json:"youth_fellowships_average,omitempty"`
-	ChildFellowshipsAvg             float64   `json:"child_fellowships_average,omitempty"`
-	OutreachAvg                     float64   `json:"outreach_average,omitempty"`
-	TrainingOrSeminarsAvg           float64   `json:"training_or_seminars_average,omitempty"`
-	LeadershipConferencesAvg        float64   `json:"leadership_conferences_average,omitempty"`
-	LeadershipTrainingAvg           float64   `json:"leadership_training_average,omitempty"`
-	OthersAvg                       float64   `json:"others_average,omitempty"`
-	FamilyDaysAvg                   float64   `json:"family_days_average,omitempty"`
-	TithesAndOfferingsAvg           float64   `json:"tithes_and_offerings_average,omitempty"`
-	HomeVisited                     []int     `json:"home_visited,omitempty"`
-	BibleStudyOrGroupLed            []int     `json:"bible_study_or_group_led,omitempty"`
-	SermonOrMessagePreached         []int     `json:"sermon_or_message_preached,omitempty"`
-	PersonNewlyContacted            []int     `json:"person_newly_contacted,omitempty"`
-	PersonFollowedUp                []int     `json:"person_followed_up,omitempty"`
-	PersonLedToChrist               []int     `json:"person_led_to_christ,omitempty"`
-	Names                           []string  `json:"names,omitempty"`
-	HomeVisitedAvg                  float64   `json:"home_visited_average,omitempty"`
-	BibleStudyOrGroupLedAvg         float64   `json:"bible_study_or_group_led_average,omitempty"`
-	SermonOrMessagePreachedAvg      float64   `json:"sermon_or_message_preached_average,omitempty"`
-	PersonNewlyContactedAvg         float64   `json:"person_newly_contacted_average,omitempty"`
-	PersonFollowedUpAvg             float64   `json:"person_followed_up_average,omitempty"`
-	PersonLedToChristAvg            float64   `json:"person_led_to_christ_average,omitempty"`
-	NarrativeReport                 string    `json:"narrative_report"`
-	ChallengesAndProblemEncountered string    `json:"challenges_and_problem_encountered"`
-	PrayerRequest                   string    `json:"prayer_request"`
-	CreatedAt                       time.Time `json:"created_at"`
-	UpdatedAt                       time.Time `json:"updated_at"`
+	Id                              int             `json:"id"`
+	MonthOf                         Period          `json:"month_of"`
+	WorkerId                        int             `json:"worker_id"`
+	WorkerName                      string          `json:"worker_name"`
+	ChurchId                        int             `json:"church_id"`
+	AreaId                          int             `json:"area_id"`
+	AreaOfAssignment                string          `json:"area_of_assignment"`
+	NameOfChurch                    string          `json:"name_of_church"`
+	WorshipService                  []int           `json:"worship_service,omitempty"`
+	SundaySchool                    []int           `json:"sunday_school,omitempty"`
+	PrayerMeetings                  []int           `json:"prayer_meetings,omitempty"`
+	BibleStudies                    []int           `json:"bible_studies,omitempty"`
+	MensFellowships                 []int           `json:"mens_fellowships,omitempty"`
+	WomensFellowships               []int           `json:"womens_fellowships,omitempty"`
+	YouthFellowships                []int           `json:"youth_fellowships,omitempty"`
+	ChildFellowships                []int           `json:"child_fellowships,omitempty"`
+	Outreach                        []int           `json:"outreach,omitempty"`
+	TrainingOrSeminars              []int           `json:"training_or_seminars,omitempty"`
+	LeadershipConferences           []int           `json:"leadership_conferences,omitempty"`
+	LeadershipTraining              []int           `json:"leadership_training,omitempty"`
+	Others                          []int           `json:"others,omitempty"`
+	FamilyDays                      []int           `json:"family_days,omitempty"`
+	TithesAndOfferings              []Money         `json:"tithes_and_offerings,omitempty"`
+	Currency                        string          `json:"currency"`
+	WorshipServiceAvg               float64         `json:"worship_service_average,omitempty"`
+	SundaySchoolAvg                 float64         `json:"sunday_school_average,omitempty"`
+	PrayerMeetingsAvg               float64         `json:"prayer_meetings_average,omitempty"`
+	BibleStudiesAvg                 float64         `json:"bible_studies_average,omitempty"`
+	MensFellowshipsAvg              float64         `json:"mens_fellowships_average,omitempty"`
+	WomensFellowshipsAvg            float64         `json:"womens_fellowships_average,omitempty"`
+	YouthFellowshipsAvg             float64         `json:"youth_fellowships_average,omitempty"`
+	ChildFellowshipsAvg             float64         `json:"child_fellowships_average,omitempty"`
+	OutreachAvg                     float64         `json:"outreach_average,omitempty"`
+	TrainingOrSeminarsAvg           float64         `json:"training_or_seminars_average,omitempty"`
+	LeadershipConferencesAvg        float64         `json:"leadership_conferences_average,omitempty"`
+	LeadershipTrainingAvg           float64         `json:"leadership_training_average,omitempty"`
+	OthersAvg                       float64         `json:"others_average,omitempty"`
+	FamilyDaysAvg                   float64         `json:"family_days_average,omitempty"`
+	HomeVisited                     []int           `json:"home_visited,omitempty"`
+	BibleStudyOrGroupLed            []int           `json:"bible_study_or_group_led,omitempty"`
+	SermonOrMessagePreached         []int           `json:"sermon_or_message_preached,omitempty"`
+	PersonNewlyContacted            []int           `json:"person_newly_contacted,omitempty"`
+	PersonFollowedUp                []int           `json:"person_followed_up,omitempty"`
+	PersonLedToChrist               []int           `json:"person_led_to_christ,omitempty"`
+	People                          []*ReportPerson `json:"people,omitempty"`
+	HomeVisitedAvg                  float64         `json:"home_visited_average,omitempty"`
+	BibleStudyOrGroupLedAvg         float64         `json:"bible_study_or_group_led_average,omitempty"`
+	SermonOrMessagePreachedAvg      float64         `json:"sermon_or_message_preached_average,omitempty"`
+	PersonNewlyContactedAvg         float64         `json:"person_newly_contacted_average,omitempty"`
+	PersonFollowedUpAvg             float64         `json:"person_followed_up_average,omitempty"`
+	PersonLedToChristAvg            float64         `json:"person_led_to_christ_average,omitempty"`
+	NarrativeReport                 string          `json:"narrative_report"`
+	ChallengesAndProblemEncountered string          `json:"challenges_and_problem_encountered"`
+	PrayerRequest                   string          `json:"prayer_request"`
+	Status                          ReportStatus    `json:"status"`
+	ReviewNote                      string          `json:"review_note,omitempty"`
+	SubmittedAt                     *time.Time      `json:"submitted_at,omitempty"`
+	ReviewedAt                      *time.Time      `json:"reviewed_at,omitempty"`
+	ReviewedBy                      int             `json:"reviewed_by,omitempty"`
+	DeletedAt                       *time.Time      `json:"deleted_at,omitempty"`
+	DeletedBy                       int             `json:"deleted_by,omitempty"`
+	Version                         int             `json:"version"`
+	CreatedAt                       time.Time       `json:"created_at"`
+	UpdatedAt                       time.Time       `json:"updated_at"`
 }
 
 type SearchReportQuery struct {
-	MonthOf    string `form:"month_of"`
-	WorkerName string `form:"worker_name"`
-	Page       int    `form:"page"`
-	PerPage    int    `form:"per_page"`
+	MonthOf    Period       `form:"month_of"`
+	MonthFrom  Period       `form:"month_from"`
+	MonthTo    Period       `form:"month_to"`
+	Year       int          `form:"year"`
+	WorkerId   int          `form:"worker_id"`
+	WorkerName string       `form:"worker_name"`
+	ChurchId   int          `form:"church_id"`
+	AreaId     int          `form:"area_id"`
+	DistrictId int          `form:"district_id"`
+	RegionId   int          `form:"region_id"`
+	Status     ReportStatus `form:"status"`
+	Q          string       `form:"q"`
+	Page       int          `form:"page"`
+	PerPage    int          `form:"per_page"`
+
+	// Case-insensitive parts of the area and church names
+	AreaOfAssignment string `form:"area_of_assignment"`
+	NameOfChurch     string `form:"name_of_church"`
+
+	// Inclusive bounds on when reports were created and last changed
+	CreatedFrom time.Time `form:"created_from"`
+	CreatedTo   time.Time `form:"created_to"`
+	UpdatedFrom time.Time `form:"updated_from"`
+	UpdatedTo   time.Time `form:"updated_to"`
+
+	// Inclusive bounds on the weekly average of activities, keyed by
+	// activity key; the average is rounded as in the report
+	AverageMin map[string]float64 `form:"-"`
+	AverageMax map[string]float64 `form:"-"`
+
+	// Sort orders the reports; ties, and an empty sort, go by id
+	Sort []SortField `form:"-"`
+
+	// Cursor continues the list from a report of a previous page, in place
+	// of Page; its sort replaces Sort
+	Cursor *ReportCursor `form:"-"`
+
+	// SkipTotal leaves out the count of every matching report
+	SkipTotal bool `form:"-"`
+
+	// Scope is set by the service from the caller's role, never from input
+	Scope *ReportScope `form:"-" json:"-"`
+
+	// Deleted selects the trash instead of the live reports
+	Deleted bool `form:"-" json:"-"`
 }
 
 type SearchReportResult struct {
-	TotalCount int       `json:"total_count"`
+	TotalCount *int      `json:"total_count,omitempty"`
 	Reports    []*Report `json:"reports"`
-	Page       int       `json:"page"`
+	Page       int       `json:"page,omitempty"`
 	PerPage    int       `json:"per_page"`
+
+	// Cursors of the pages around this one; empty at either end
+	NextCursor string `json:"next_cursor,omitempty"`
+	PrevCursor string `json:"prev_cursor,omitempty"`
 }
 
 // Helper function to calculate average attendance
@@ -0,0 +1,81 @@
+package model
+
+import (
+	"fmt"
+	"strings"
+)
+
+type ReportStatus string
+
+const (
+	// StatusDraft is a report the worker is still filling in
+	StatusDraft ReportStatus = "draft"
+	// StatusSubmitted is waiting for a supervisor's review
+	StatusSubmitted ReportStatus = "submitted"
+	StatusApproved  ReportStatus = "approved"
+	// StatusReturned was sent back to the worker with a review note
+	StatusReturned ReportStatus = "returned"
+)
+
+func (s ReportStatus) Valid() bool {
+	switch s {
+	case StatusDraft, StatusSubmitted, StatusApproved, StatusReturned:
+		return true
+	}
+	return false
+}
+
+// Editable reports whether the worker may still change the report
+func (s ReportStatus) Editable() bool {
+	return s == StatusDraft || s == StatusReturned
+}
+
+// reportTransitions lists the statuses each status may move to
+var reportTransitions = map[ReportStatus][]ReportStatus{
+	StatusDraft:     {StatusSubmitted},
+	StatusReturned:  {StatusSubmitted},
+	StatusSubmitted: {StatusApproved, StatusReturned},
+}
+
+// CanTransition reports whether a report may move from s to next
+func (s ReportStatus) CanTransition(next ReportStatus) bool {
+	for _, allowed := range reportTransitions[s] {
+		if allowed == next {
+			return true
+		}
+	}
+	return false
+}
+
+// ValidateForSubmit applies the checks a finished report must pass. Drafts
+// are saved without the required ones.
+func (r *Report) ValidateForSubmit() error {
+	v := &ValidationError{}
+
+	if len(r.WorshipService) == 0 {
+		v.Add("worship_service", CodeRequired, "must not be empty")
+	}
+	if len(r.SundaySchool) == 0 {
+		v.Add("sunday_school", CodeRequired, "must not be empty")
+	}
+	if strings.TrimSpace(r.NarrativeReport) == "" {
+		v.Add("narrative_report", CodeRequired, "must not be empty")
+	}
+	if strings.TrimSpace(r.ChallengesAndProblemEncountered) == "" {
+		v.Add("challenges_and_problem_encountered", CodeRequired, "must not be empty")
+	}
+	if strings.TrimSpace(r.PrayerRequest) == "" {
+		v.Add("prayer_request", CodeRequired, "must not be empty")
+	}
+
+	for i, person := range r.People {
+		if person != nil && person.Category == "" {
+			v.Add("people", CodeRequired, fmt.Sprintf("person %d: category must not be empty", i+1))
+		}
+	}
+	r.validatePeopleCounts(v)
+
+	r.ValidateFields(v)
+
+	return v.Err()
+}
@@ -0,0 +1,30 @@
+package model
+
+import "time"
+
+type RevisionAction string
+
+const (
+	ActionCreate  RevisionAction = "create"
+	ActionUpdate  RevisionAction = "update"
+	ActionDelete  RevisionAction = "delete"
+	ActionSubmit  RevisionAction = "submit"
+	ActionApprove RevisionAction = "approve"
+	ActionReturn  RevisionAction = "return"
+	ActionRestore RevisionAction = "restore"
+	ActionPurge   RevisionAction = "purge"
+)
+
+// ReportRevision records one change to a report: who made it, when, the
+// fields it changed and the report as it stood afterwards. A purge has no
+// snapshot; the report is gone for good.
+type ReportRevision struct {
+	Id        int            `json:"id"`
+	ReportId  int            `json:"report_id"`
+	Action    RevisionAction `json:"action"`
+	ActorId   int            `json:"actor_id"`
+	ActorName string         `json:"actor_name"`
+	Changes   []*FieldChange `json:"changes"`
+	Snapshot  *Report        `json:"-"`
+	CreatedAt time.Time      `json:"created_at"`
+}
@@ -0,0 +1,23 @@
+package model
+
+// ReportSearchHit is a report matching a full-text search. Highlights holds
+// a passage of each text field that matched, keyed by its JSON name, with
+// the matching words wrapped in <mark></mark>; the rest is HTML-escaped.
+type ReportSearchHit struct {
+	ReportId         int               `json:"report_id"`
+	MonthOf          Period            `json:"month_of"`
+	WorkerId         int               `json:"worker_id"`
+	WorkerName       string            `json:"worker_name"`
+	AreaOfAssignment string            `json:"area_of_assignment"`
+	NameOfChurch     string            `json:"name_of_church"`
+	Status           ReportStatus      `json:"status"`
+	Rank             float64           `json:"rank"`
+	Highlights       map[string]string `json:"highlights"`
+}
+
+type ReportSearchResult struct {
+	TotalCount int                `json:"total_count"`
+	Hits       []*ReportSearchHit `json:"hits"`
+	Page       int                `json:"page"`
+	PerPage    int                `json:"per_page"`
+}
@@ -0,0 +1,51 @@
+package model
+
+import (
+	"fmt"
+	"strings"
+)
+
+// SortField is one key of a sort order, such as "-month_of"
+type SortField struct {
+	Key  string
+	Desc bool
+}
+
+// ReportSortKeys lists the keys the report list can be sorted by: the fixed
+// ones below and <activity>_average for every activity
+var ReportSortKeys = reportSortKeys()
+
+func reportSortKeys() map[string]bool {
+	keys := map[string]bool{}
+	for _, key := range []string{"id", "month_of", "worker_name", "area_of_assignment", "name_of_church", "status", "created_at", "updated_at"} {
+		keys[key] = true
+	}
+	for _, activity := range Activities {
+		keys[activity.Key+"_average"] = true
+	}
+	return keys
+}
+
+// ParseReportSort reads a comma-separated list of sort keys, each ascending
+// unless prefixed with "-", as in "-month_of,worker_name"
+func ParseReportSort(value string) ([]SortField, error) {
+	var fields []SortField
+	seen := map[string]bool{}
+	for _, part := range strings.Split(value, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+
+		field := SortField{Key: strings.TrimPrefix(part, "-"), Desc: strings.HasPrefix(part, "-")}
+		if !ReportSortKeys[field.Key] {
+			return nil, fmt.Errorf("cannot sort by %s", field.Key)
+		}
+		if seen[field.Key] {
+			return nil, fmt.Errorf("%s is sorted by twice", field.Key)
+		}
+		seen[field.Key] = true
+		fields = append(fields, field)
+	}
+	return fields, nil
+}
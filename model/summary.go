@@ -0,0 +1,126 @@
+package model
+
+// ActivitySummary aggregates one weekly array over several reports. Weeks
+// holds the sum of every report for each week of the month.
+type ActivitySummary struct {
+	Key     string       `json:"key"`
+	Label   string       `json:"label"`
+	Kind    ActivityKind `json:"kind"`
+	Weeks   []int        `json:"weeks"`
+	Total   int          `json:"total"`
+	Average float64      `json:"average"`
+}
+
+// ChurchSummary is one line of the per-church breakdown of an area
+type ChurchSummary struct {
+	ChurchId    int                `json:"church_id"`
+	Name        string             `json:"name"`
+	WorkerNames []string           `json:"worker_names"`
+	ReportCount int                `json:"report_count"`
+	Activities  []*ActivitySummary `json:"activities"`
+	Offerings   map[string]Money   `json:"tithes_and_offerings_total"`
+}
+
+// AreaSummary consolidates the reports of every church in an area for one
+// month
+type AreaSummary struct {
+	AreaId      int                `json:"area_id"`
+	AreaName    string             `json:"area_name"`
+	MonthOf     Period             `json:"month_of"`
+	ReportCount int                `json:"report_count"`
+	Activities  []*ActivitySummary `json:"activities"`
+	Offerings   map[string]Money   `json:"tithes_and_offerings_total"`
+	Churches    []*ChurchSummary   `json:"churches"`
+}
+
+// Activity returns the summary of the activity with the given key
+func (s *AreaSummary) Activity(key string) *ActivitySummary {
+	for _, activity := range s.Activities {
+		if activity.Key == key {
+			return activity
+		}
+	}
+	return nil
+}
+
+// SummarizeActivities adds up every activity week by week over the reports.
+// The average is the mean of those weekly sums.
+func SummarizeActivities(reports []*Report) []*ActivitySummary {
+	summaries := make([]*ActivitySummary, 0, len(Activities))
+	for _, activity := range Activities {
+		var weeks []int
+		for _, report := range reports {
+			for i, v := range activity.Values(report) {
+				if i >= len(weeks) {
+					weeks = append(weeks, make([]int, i-len(weeks)+1)...)
+				}
+				weeks[i] += v
+			}
+		}
+		if weeks == nil {
+			weeks = []int{}
+		}
+
+		summaries = append(summaries, &ActivitySummary{
+			Key:     activity.Key,
+			Label:   activity.Label,
+			Kind:    activity.Kind,
+			Weeks:   weeks,
+			Total:   Sum(weeks),
+			Average: CalculateAverage(weeks),
+		})
+	}
+	return summaries
+}
+
+// SumOfferings totals the tithes and offerings of the reports per currency
+func SumOfferings(reports []*Report) map[string]Money {
+	totals := map[string]Money{}
+	for _, report := range reports {
+		if len(report.TithesAndOfferings) == 0 {
+			continue
+		}
+		totals[report.Currency] += SumMoney(report.TithesAndOfferings)
+	}
+	return totals
+}
+
+// SummarizeArea builds the area summary and the per-church breakdown, with
+// churches in the order they first appear in reports
+func SummarizeArea(area *OrgUnit, monthOf Period, reports []*Report) *AreaSummary {
+	summary := &AreaSummary{
+		AreaId:      area.Id,
+		AreaName:    area.Name,
+		MonthOf:     monthOf,
+		ReportCount: len(reports),
+		Activities:  SummarizeActivities(reports),
+		Offerings:   SumOfferings(reports),
+		Churches:    []*ChurchSummary{},
+	}
+
+	byChurch := map[int][]*Report{}
+	var churchIds []int
+	for _, report := range reports {
+		if _, ok := byChurch[report.ChurchId]; !ok {
+			churchIds = append(churchIds, report.ChurchId)
+		}
+		byChurch[report.ChurchId] = append(byChurch[report.ChurchId], report)
+	}
+
+	for _, id := range churchIds {
+		churchReports := byChurch[id]
+		church := &ChurchSummary{
+			ChurchId:    id,
+			Name:        churchReports[0].NameOfChurch,
+			ReportCount: len(churchReports),
+			Activities:  SummarizeActivities(churchReports),
+			Offerings:   SumOfferings(churchReports),
+		}
+		for _, report := range churchReports {
+			church.WorkerNames = append(church.WorkerNames, report.WorkerName)
+		}
+		summary.Churches = append(summary.Churches, church)
+	}
+
+	return summary
+}
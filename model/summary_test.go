@@ -0,0 +1,113 @@
+package model
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestSummarizeArea(t *testing.T) {
+	area := &OrgUnit{Id: 3, Level: LevelArea, Name: "North"}
+	monthOf := NewPeriod(2024, time.March)
+
+	tests := []struct {
+		name          string
+		reports       []*Report
+		wantWeeks     []int
+		wantTotal     int
+		wantAverage   float64
+		wantOfferings map[string]Money
+		wantChurches  []*ChurchSummary
+	}{
+		{
+			name:          "no reports",
+			wantWeeks:     []int{},
+			wantOfferings: map[string]Money{},
+			wantChurches:  []*ChurchSummary{},
+		},
+		{
+			name: "reports without weeks",
+			reports: []*Report{
+				{ChurchId: 7, NameOfChurch: "Grace", WorkerName: "Juan", Currency: "PHP"},
+				{ChurchId: 7, NameOfChurch: "Grace", WorkerName: "Maria", Currency: "PHP"},
+			},
+			wantWeeks:     []int{},
+			wantOfferings: map[string]Money{},
+			wantChurches: []*ChurchSummary{
+				{ChurchId: 7, Name: "Grace", WorkerNames: []string{"Juan", "Maria"}, ReportCount: 2, Offerings: map[string]Money{}},
+			},
+		},
+		{
+			name: "weeks of different lengths and mixed currencies",
+			reports: []*Report{
+				{
+					ChurchId: 7, NameOfChurch: "Grace", WorkerName: "Juan", Currency: "PHP",
+					WorshipService:     []int{40, 45, 50, 41},
+					TithesAndOfferings: []Money{150000, 125050},
+				},
+				{
+					ChurchId: 9, NameOfChurch: "Hope", WorkerName: "Pedro", Currency: "USD",
+					WorshipService:     []int{10, 12, 11, 9, 13},
+					TithesAndOfferings: []Money{2500},
+				},
+				{
+					ChurchId: 7, NameOfChurch: "Grace", WorkerName: "Maria", Currency: "PHP",
+					WorshipService:     []int{5, 5},
+					TithesAndOfferings: []Money{10000},
+				},
+			},
+			// The fifth week only has Pedro's count
+			wantWeeks:     []int{55, 62, 61, 50, 13},
+			wantTotal:     241,
+			wantAverage:   48,
+			wantOfferings: map[string]Money{"PHP": 285050, "USD": 2500},
+			wantChurches: []*ChurchSummary{
+				{ChurchId: 7, Name: "Grace", WorkerNames: []string{"Juan", "Maria"}, ReportCount: 2, Offerings: map[string]Money{"PHP": 285050}},
+				{ChurchId: 9, Name: "Hope", WorkerNames: []string{"Pedro"}, ReportCount: 1, Offerings: map[string]Money{"USD": 2500}},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		summary := SummarizeArea(area, monthOf, tt.reports)
+
+		if summary.AreaId != area.Id || summary.AreaName != area.Name || summary.MonthOf != monthOf {
+			t.Errorf("%s: summary area, month = %d %q %v", tt.name, summary.AreaId, summary.AreaName, summary.MonthOf)
+		}
+		if summary.ReportCount != len(tt.reports) {
+			t.Errorf("%s: report count = %d, want %d", tt.name, summary.ReportCount, len(tt.reports))
+		}
+		if len(summary.Activities) != len(Activities) {
+			t.Fatalf("%s: %d activities, want %d", tt.name, len(summary.Activities), len(Activities))
+		}
+
+		worship := summary.Activity("worship_service")
+		if !reflect.DeepEqual(worship.Weeks, tt.wantWeeks) || worship.Total != tt.wantTotal || worship.Average != tt.wantAverage {
+			t.Errorf("%s: worship service = %v total %d average %v, want %v total %d average %v",
+				tt.name, worship.Weeks, worship.Total, worship.Average, tt.wantWeeks, tt.wantTotal, tt.wantAverage)
+		}
+		if other := summary.Activity("sunday_school"); len(other.Weeks) != 0 || other.Total != 0 || other.Average != 0 {
+			t.Errorf("%s: sunday school = %v total %d average %v, want nothing", tt.name, other.Weeks, other.Total, other.Average)
+		}
+		if !reflect.DeepEqual(summary.Offerings, tt.wantOfferings) {
+			t.Errorf("%s: offerings = %v, want %v", tt.name, summary.Offerings, tt.wantOfferings)
+		}
+
+		if len(summary.Churches) != len(tt.wantChurches) {
+			t.Fatalf("%s: %d churches, want %d", tt.name, len(summary.Churches), len(tt.wantChurches))
+		}
+		for i, church := range summary.Churches {
+			want := tt.wantChurches[i]
+			if church.ChurchId != want.ChurchId || church.Name != want.Name || church.ReportCount != want.ReportCount {
+				t.Errorf("%s: church %d = %d %q with %d reports, want %d %q with %d", tt.name, i,
+					church.ChurchId, church.Name, church.ReportCount, want.ChurchId, want.Name, want.ReportCount)
+			}
+			if !reflect.DeepEqual(church.WorkerNames, want.WorkerNames) {
+				t.Errorf("%s: church %d workers = %v, want %v", tt.name, i, church.WorkerNames, want.WorkerNames)
+			}
+			if !reflect.DeepEqual(church.Offerings, want.Offerings) {
+				t.Errorf("%s: church %d offerings = %v, want %v", tt.name, i, church.Offerings, want.Offerings)
+			}
+		}
+	}
+}
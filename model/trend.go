@@ -0,0 +1,87 @@
+package model
+
+import "math"
+
+// TrendQuery selects the reports of a worker, church or area over a range
+// of months
+type TrendQuery struct {
+	WorkerId   int
+	ChurchId   int
+	AreaId     int
+	MonthFrom  Period
+	MonthTo    Period
+	Activities []Activity
+}
+
+// TrendPoint is the value of one activity for one month. Average is the
+// mean of the weekly averages of the month's reports and SumOfAverages their
+// sum, which is the combined weekly attendance of a church or area.
+type TrendPoint struct {
+	MonthOf       Period  `json:"month_of"`
+	ReportCount   int     `json:"report_count"`
+	Average       float64 `json:"average"`
+	SumOfAverages float64 `json:"sum_of_averages"`
+}
+
+type TrendSeries struct {
+	Key    string        `json:"key"`
+	Label  string        `json:"label"`
+	Kind   ActivityKind  `json:"kind"`
+	Points []*TrendPoint `json:"points"`
+}
+
+type TrendResult struct {
+	MonthFrom Period         `json:"month_from"`
+	MonthTo   Period         `json:"month_to"`
+	Series    []*TrendSeries `json:"series"`
+}
+
+// BuildTrends computes one point per month of the range for every activity.
+// Months without reports are kept with a zero count so series line up.
+func BuildTrends(query *TrendQuery, reports []*Report) *TrendResult {
+	months := query.MonthFrom.MonthsUntil(query.MonthTo) + 1
+	if months < 0 {
+		months = 0
+	}
+
+	byMonth := make([][]*Report, months)
+	for _, report := range reports {
+		i := query.MonthFrom.MonthsUntil(report.MonthOf)
+		if i >= 0 && i < months {
+			byMonth[i] = append(byMonth[i], report)
+		}
+	}
+
+	result := &TrendResult{
+		MonthFrom: query.MonthFrom,
+		MonthTo:   query.MonthTo,
+		Series:    make([]*TrendSeries, 0, len(query.Activities)),
+	}
+
+	for _, activity := range query.Activities {
+		series := &TrendSeries{
+			Key:    activity.Key,
+			Label:  activity.Label,
+			Kind:   activity.Kind,
+			Points: make([]*TrendPoint, 0, months),
+		}
+
+		for i, monthReports := range byMonth {
+			point := &TrendPoint{
+				MonthOf:     query.MonthFrom.AddMonths(i),
+				ReportCount: len(monthReports),
+			}
+			for _, report := range monthReports {
+				point.SumOfAverages += CalculateAverage(activity.Values(report))
+			}
+			if point.ReportCount > 0 {
+				point.Average = math.Round(point.SumOfAverages/float64(point.ReportCount)*100) / 100
+			}
+			series.Points = append(series.Points, point)
+		}
+
+		result.Series = append(result.Series, series)
+	}
+
+	return result
+}
@@ -0,0 +1,133 @@
+package model
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestBuildTrends(t *testing.T) {
+	worship, _ := FindActivity("worship_service")
+	visits, _ := FindActivity("home_visited")
+
+	tests := []struct {
+		name       string
+		monthFrom  Period
+		monthTo    Period
+		reports    []*Report
+		wantMonths []Period
+		wantCounts []int
+		// Averages and sums of worship service attendance, month by month
+		wantAverages []float64
+		wantSums     []float64
+	}{
+		{
+			name:      "months without reports are filled in",
+			monthFrom: NewPeriod(2024, time.January),
+			monthTo:   NewPeriod(2024, time.April),
+			reports: []*Report{
+				{MonthOf: NewPeriod(2024, time.January), WorshipService: []int{40, 42, 44, 46}},
+				{MonthOf: NewPeriod(2024, time.January), WorshipService: []int{11, 11, 12, 12}},
+				{MonthOf: NewPeriod(2024, time.April), WorshipService: []int{50, 50}},
+			},
+			wantMonths: []Period{
+				NewPeriod(2024, time.January), NewPeriod(2024, time.February),
+				NewPeriod(2024, time.March), NewPeriod(2024, time.April),
+			},
+			wantCounts: []int{2, 0, 0, 1},
+			// 43 and 12 (11.5 rounded) average to 27.5
+			wantAverages: []float64{27.5, 0, 0, 50},
+			wantSums:     []float64{55, 0, 0, 50},
+		},
+		{
+			name:      "range across the year boundary",
+			monthFrom: NewPeriod(2023, time.November),
+			monthTo:   NewPeriod(2024, time.February),
+			reports: []*Report{
+				{MonthOf: NewPeriod(2023, time.December), WorshipService: []int{30, 32}},
+				{MonthOf: NewPeriod(2024, time.January), WorshipService: []int{35}},
+				// Outside the range on either side
+				{MonthOf: NewPeriod(2023, time.October), WorshipService: []int{99}},
+				{MonthOf: NewPeriod(2024, time.March), WorshipService: []int{99}},
+				// Same month number a year off
+				{MonthOf: NewPeriod(2024, time.December), WorshipService: []int{99}},
+			},
+			wantMonths: []Period{
+				NewPeriod(2023, time.November), NewPeriod(2023, time.December),
+				NewPeriod(2024, time.January), NewPeriod(2024, time.February),
+			},
+			wantCounts:   []int{0, 1, 1, 0},
+			wantAverages: []float64{0, 31, 35, 0},
+			wantSums:     []float64{0, 31, 35, 0},
+		},
+		{
+			name:      "single month",
+			monthFrom: NewPeriod(2024, time.May),
+			monthTo:   NewPeriod(2024, time.May),
+			reports: []*Report{
+				{MonthOf: NewPeriod(2024, time.May), WorshipService: []int{20}},
+			},
+			wantMonths:   []Period{NewPeriod(2024, time.May)},
+			wantCounts:   []int{1},
+			wantAverages: []float64{20},
+			wantSums:     []float64{20},
+		},
+		{
+			name:      "range ending before it starts",
+			monthFrom: NewPeriod(2024, time.May),
+			monthTo:   NewPeriod(2024, time.March),
+			reports: []*Report{
+				{MonthOf: NewPeriod(2024, time.April), WorshipService: []int{20}},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		query := &TrendQuery{
+			MonthFrom:  tt.monthFrom,
+			MonthTo:    tt.monthTo,
+			Activities: []Activity{worship, visits},
+		}
+		result := BuildTrends(query, tt.reports)
+
+		if result.MonthFrom != tt.monthFrom || result.MonthTo != tt.monthTo {
+			t.Errorf("%s: range = %v to %v", tt.name, result.MonthFrom, result.MonthTo)
+		}
+		if len(result.Series) != 2 || result.Series[0].Key != worship.Key || result.Series[1].Key != visits.Key {
+			t.Fatalf("%s: series = %+v, want worship service then home visited", tt.name, result.Series)
+		}
+
+		var months []Period
+		var counts []int
+		var averages, sums []float64
+		for _, point := range result.Series[0].Points {
+			months = append(months, point.MonthOf)
+			counts = append(counts, point.ReportCount)
+			averages = append(averages, point.Average)
+			sums = append(sums, point.SumOfAverages)
+		}
+		if !reflect.DeepEqual(months, tt.wantMonths) {
+			t.Errorf("%s: months = %v, want %v", tt.name, months, tt.wantMonths)
+		}
+		if !reflect.DeepEqual(counts, tt.wantCounts) {
+			t.Errorf("%s: report counts = %v, want %v", tt.name, counts, tt.wantCounts)
+		}
+		if !reflect.DeepEqual(averages, tt.wantAverages) {
+			t.Errorf("%s: averages = %v, want %v", tt.name, averages, tt.wantAverages)
+		}
+		if !reflect.DeepEqual(sums, tt.wantSums) {
+			t.Errorf("%s: sums of averages = %v, want %v", tt.name, sums, tt.wantSums)
+		}
+
+		// Every series has a point for every month, even without values
+		visitPoints := result.Series[1].Points
+		if len(visitPoints) != len(tt.wantMonths) {
+			t.Errorf("%s: %d home visited points, want %d", tt.name, len(visitPoints), len(tt.wantMonths))
+		}
+		for _, point := range visitPoints {
+			if point.Average != 0 || point.SumOfAverages != 0 {
+				t.Errorf("%s: home visited in %v = %v, want 0", tt.name, point.MonthOf, point.Average)
+			}
+		}
+	}
+}
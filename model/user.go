@@ -0,0 +1,93 @@
+package model
+
+import (
+	"context"
+	"time"
+)
+
+type Role string
+
+const (
+	RoleAdmin      Role = "admin"
+	RoleSupervisor Role = "supervisor"
+	RoleWorker     Role = "worker"
+)
+
+func (r Role) Valid() bool {
+	switch r {
+	case RoleAdmin, RoleSupervisor, RoleWorker:
+		return true
+	}
+	return false
+}
+
+type User struct {
+	Id        int       `json:"id"`
+	Name      string    `json:"name"`
+	Email     string    `json:"email"`
+	Password  string    `json:"-"`
+	Role      Role      `json:"role"`
+	WorkerId  int       `json:"worker_id,omitempty"`
+	AreaIds   []int     `json:"area_ids,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// ReportScope restricts the reports a caller may see. Supervisors are limited
+// to AreaIds, everyone else to WorkerId; a nil scope means every report.
+type ReportScope struct {
+	Role     Role
+	WorkerId int
+	AreaIds  []int
+}
+
+// ReportScope returns the reports visible to the user based on their role
+func (u *User) ReportScope() *ReportScope {
+	if u.Role == RoleAdmin {
+		return nil
+	}
+	return &ReportScope{Role: u.Role, WorkerId: u.WorkerId, AreaIds: u.AreaIds}
+}
+
+// CanAccessReport reports whether the user may read or modify reports for
+// the given worker and area of assignment
+func (u *User) CanAccessReport(workerId, areaId int) bool {
+	switch u.Role {
+	case RoleAdmin:
+		return true
+	case RoleSupervisor:
+		return u.CanAccessArea(areaId)
+	case RoleWorker:
+		return u.WorkerId > 0 && u.WorkerId == workerId
+	}
+	return false
+}
+
+// CanAccessArea reports whether the user may see the reports of a whole
+// area of assignment
+func (u *User) CanAccessArea(areaId int) bool {
+	switch u.Role {
+	case RoleAdmin:
+		return true
+	case RoleSupervisor:
+		for _, id := range u.AreaIds {
+			if id == areaId {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+type currentUserKey struct{}
+
+// ContextWithUser returns a copy of ctx carrying the authenticated user
+func ContextWithUser(ctx context.Context, user *User) context.Context {
+	return context.WithValue(ctx, currentUserKey{}, user)
+}
+
+// UserFromContext returns the authenticated user stored in ctx, if any
+func UserFromContext(ctx context.Context) (*User, bool) {
+	user, ok := ctx.Value(currentUserKey{}).(*User)
+	return user, ok && user != nil
+}
@@ -0,0 +1,113 @@
+package model
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+// Codes of a FieldError, for clients that react to a failure rather than
+// show its message
+const (
+	CodeRequired     = "required"
+	CodeInvalid      = "invalid"
+	CodeNegative     = "negative"
+	CodeTooManyWeeks = "too_many_weeks"
+	CodeTooLarge     = "too_large"
+	CodeTooLong      = "too_long"
+	CodeMismatch     = "mismatch"
+)
+
+// Upper bounds that catch typing mistakes such as an extra zero
+const (
+	MaxWeeklyCount  = 10000
+	MaxWeeklyAmount = 10000000
+	MaxTextLength   = 5000
+	MaxNameLength   = 100
+)
+
+// FieldError is one failing field of a request
+type FieldError struct {
+	Field   string `json:"field"`
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+// ValidationError collects every failing field instead of stopping at the
+// first one
+type ValidationError struct {
+	Fields []*FieldError `json:"fields"`
+}
+
+func (e *ValidationError) Add(field, code, message string) {
+	e.Fields = append(e.Fields, &FieldError{Field: field, Code: code, Message: message})
+}
+
+// Err returns e when a field failed and nil otherwise
+func (e *ValidationError) Err() error {
+	if len(e.Fields) == 0 {
+		return nil
+	}
+	return e
+}
+
+func (e *ValidationError) Error() string {
+	messages := make([]string, 0, len(e.Fields))
+	for _, field := range e.Fields {
+		messages = append(messages, field.Field+": "+field.Message)
+	}
+	return strings.Join(messages, "; ")
+}
+
+// ValidateFields checks the shape of what was filled in: no more weeks than
+// the month has, counts within bounds, and texts of a sane length. Empty
+// fields pass; ValidateForSubmit decides what a finished report needs.
+func (r *Report) ValidateFields(v *ValidationError) {
+	weeks := r.MonthOf.Weeks()
+
+	for _, activity := range Activities {
+		values := activity.Values(r)
+		if len(values) > weeks {
+			v.Add(activity.Key, CodeTooManyWeeks, fmt.Sprintf("%s has %d weeks; got %d values", r.MonthOf.Label(), weeks, len(values)))
+		}
+		for i, value := range values {
+			switch {
+			case value < 0:
+				v.Add(activity.Key, CodeNegative, fmt.Sprintf("week %d must not be negative", i+1))
+			case value > MaxWeeklyCount:
+				v.Add(activity.Key, CodeTooLarge, fmt.Sprintf("week %d must not be more than %d", i+1, MaxWeeklyCount))
+			}
+		}
+	}
+
+	if len(r.TithesAndOfferings) > weeks {
+		v.Add("tithes_and_offerings", CodeTooManyWeeks, fmt.Sprintf("%s has %d weeks; got %d amounts", r.MonthOf.Label(), weeks, len(r.TithesAndOfferings)))
+	}
+	for i, amount := range r.TithesAndOfferings {
+		switch {
+		case amount < 0:
+			v.Add("tithes_and_offerings", CodeNegative, fmt.Sprintf("week %d must not be negative", i+1))
+		case amount > MaxWeeklyAmount*100:
+			v.Add("tithes_and_offerings", CodeTooLarge, fmt.Sprintf("week %d must not be more than %d", i+1, MaxWeeklyAmount))
+		}
+	}
+	if r.Currency != "" && !ValidCurrency(r.Currency) {
+		v.Add("currency", CodeInvalid, "must be a three-letter currency code such as "+DefaultCurrency)
+	}
+
+	r.validatePeople(v)
+
+	texts := []struct {
+		field string
+		value string
+	}{
+		{"narrative_report", r.NarrativeReport},
+		{"challenges_and_problem_encountered", r.ChallengesAndProblemEncountered},
+		{"prayer_request", r.PrayerRequest},
+	}
+	for _, text := range texts {
+		if utf8.RuneCountInString(text.value) > MaxTextLength {
+			v.Add(text.field, CodeTooLong, fmt.Sprintf("must not be longer than %d characters", MaxTextLength))
+		}
+	}
+}
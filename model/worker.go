@@ -0,0 +1,31 @@
+package model
+
+import "time"
+
+type Worker struct {
+	Id          int       `json:"id"`
+	Name        string    `json:"name"`
+	ReportCount int       `json:"report_count"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+type SearchWorkerQuery struct {
+	Name    string `form:"name"`
+	Page    int    `form:"page"`
+	PerPage int    `form:"per_page"`
+}
+
+type SearchWorkerResult struct {
+	TotalCount int       `json:"total_count"`
+	Workers    []*Worker `json:"workers"`
+	Page       int       `json:"page"`
+	PerPage    int       `json:"per_page"`
+}
+
+// DuplicateWorkers is a group of workers whose names look like spellings of
+// the same person
+type DuplicateWorkers struct {
+	Key     string    `json:"key"`
+	Workers []*Worker `json:"workers"`
+}
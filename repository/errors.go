@@ -0,0 +1,46 @@
+package repository
+
+import (
+	"errors"
+
+	"github.com/lib/pq"
+)
+
+// ErrReportTaken is returned when a write would create a second report for
+// the same worker and month
+var ErrReportTaken = errors.New("a report for this worker and month already exists")
+
+// ErrReportModified is returned when a report was changed by someone else
+// since the version being written was read
+var ErrReportModified = errors.New("the report has been changed since it was read")
+
+// isUniqueViolation reports whether err is a PostgreSQL unique_violation
+// raised by the named constraint or index
+func isUniqueViolation(err error, constraint string) bool {
+	var pqErr *pq.Error
+	return errors.As(err, &pqErr) && pqErr.Code == "23505" && pqErr.Constraint == constraint
+}
+
+// ErrWorkerNameTaken is returned when another worker already has the name
+var ErrWorkerNameTaken = errors.New("a worker with this name already exists")
+
+// ErrWorkerMergedIntoItself is returned when the target of a merge is also
+// one of its sources
+var ErrWorkerMergedIntoItself = errors.New("a worker cannot be merged into itself")
+
+// ErrWorkerInUse is returned when deleting a worker that still has reports
+// or a user account
+var ErrWorkerInUse = errors.New("worker still has reports or a user account")
+
+// isForeignKeyViolation reports whether err is a PostgreSQL foreign_key_violation
+func isForeignKeyViolation(err error) bool {
+	var pqErr *pq.Error
+	return errors.As(err, &pqErr) && pqErr.Code == "23503"
+}
+
+// ErrOrgUnitNameTaken is returned when a sibling already has the name
+var ErrOrgUnitNameTaken = errors.New("a unit with this name already exists under the same parent")
+
+// ErrOrgUnitInUse is returned when deleting a unit that still has children
+// or reports
+var ErrOrgUnitInUse = errors.New("unit still has child units or reports")
@@ -0,0 +1,15 @@
+package repository
+
+import (
+	"context"
+	"reports/model"
+)
+
+type OrganizationRepository interface {
+	Save(ctx context.Context, unit *model.OrgUnit) error
+	Update(ctx context.Context, unit *model.OrgUnit) error
+	Delete(ctx context.Context, level model.OrgLevel, id int) error
+	FindById(ctx context.Context, level model.OrgLevel, id int) (*model.OrgUnit, error)
+	FindAll(ctx context.Context, query *model.SearchOrgUnitQuery) ([]*model.OrgUnit, error)
+	Rollup(ctx context.Context, query *model.RollupQuery) ([]*model.RollupRow, error)
+}
@@ -0,0 +1,391 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"reports/helper"
+	"reports/model"
+	"strconv"
+	"strings"
+)
+
+type OrganizationRepositoryImpl struct {
+	Db *sql.DB
+}
+
+func NewOrganizationRepository(Db *sql.DB) OrganizationRepository {
+	return &OrganizationRepositoryImpl{Db: Db}
+}
+
+// orgTable describes where a level of the hierarchy is stored. alias is the
+// table alias used for the level in reportFrom.
+type orgTable struct {
+	table        string
+	alias        string
+	parentColumn string
+	parentTable  string
+}
+
+var orgTables = map[model.OrgLevel]orgTable{
+	model.LevelRegion:   {table: "regions", alias: "r"},
+	model.LevelDistrict: {table: "districts", alias: "d", parentColumn: "region_id", parentTable: "regions"},
+	model.LevelArea:     {table: "areas", alias: "a", parentColumn: "district_id", parentTable: "districts"},
+	model.LevelChurch:   {table: "churches", alias: "c", parentColumn: "area_id", parentTable: "areas"},
+}
+
+func lookupOrgTable(level model.OrgLevel) (orgTable, error) {
+	table, ok := orgTables[level]
+	if !ok {
+		return orgTable{}, fmt.Errorf("unknown level %q", level)
+	}
+	return table, nil
+}
+
+// Save implements OrganizationRepository
+func (r *OrganizationRepositoryImpl) Save(ctx context.Context, unit *model.OrgUnit) error {
+	table, err := lookupOrgTable(unit.Level)
+	if err != nil {
+		return err
+	}
+
+	tx, err := r.Db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer helper.CommitOrRollback(tx)
+
+	var rawSQL string
+	var params []interface{}
+	if table.parentColumn == "" {
+		rawSQL = `INSERT INTO ` + table.table + ` (name, created_at, updated_at) VALUES ($1, $2, $3) RETURNING id`
+		params = []interface{}{unit.Name, unit.CreatedAt, unit.UpdatedAt}
+	} else {
+		rawSQL = `INSERT INTO ` + table.table + ` (` + table.parentColumn + `, name, created_at, updated_at) VALUES ($1, $2, $3, $4) RETURNING id`
+		params = []interface{}{unit.ParentId, unit.Name, unit.CreatedAt, unit.UpdatedAt}
+	}
+
+	err = tx.QueryRowContext(ctx, rawSQL, params...).Scan(&unit.Id)
+	if err != nil {
+		if isUniqueViolation(err, table.table+"_name_key") {
+			return ErrOrgUnitNameTaken
+		}
+		return err
+	}
+
+	return nil
+}
+
+// Update implements OrganizationRepository
+func (r *OrganizationRepositoryImpl) Update(ctx context.Context, unit *model.OrgUnit) error {
+	table, err := lookupOrgTable(unit.Level)
+	if err != nil {
+		return err
+	}
+
+	tx, err := r.Db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer helper.CommitOrRollback(tx)
+
+	var rawSQL string
+	var params []interface{}
+	if table.parentColumn == "" {
+		rawSQL = `UPDATE ` + table.table + ` SET name = $1, updated_at = $2 WHERE id = $3`
+		params = []interface{}{unit.Name, unit.UpdatedAt, unit.Id}
+	} else {
+		rawSQL = `UPDATE ` + table.table + ` SET ` + table.parentColumn + ` = $1, name = $2, updated_at = $3 WHERE id = $4`
+		params = []interface{}{unit.ParentId, unit.Name, unit.UpdatedAt, unit.Id}
+	}
+
+	_, err = tx.ExecContext(ctx, rawSQL, params...)
+	if err != nil {
+		if isUniqueViolation(err, table.table+"_name_key") {
+			return ErrOrgUnitNameTaken
+		}
+		return err
+	}
+
+	return nil
+}
+
+// Delete implements OrganizationRepository
+func (r *OrganizationRepositoryImpl) Delete(ctx context.Context, level model.OrgLevel, id int) error {
+	table, err := lookupOrgTable(level)
+	if err != nil {
+		return err
+	}
+
+	tx, err := r.Db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer helper.CommitOrRollback(tx)
+
+	_, err = tx.ExecContext(ctx, `DELETE FROM `+table.table+` WHERE id = $1`, id)
+	if err != nil {
+		if isForeignKeyViolation(err) {
+			return ErrOrgUnitInUse
+		}
+		return err
+	}
+
+	return nil
+}
+
+// FindById implements OrganizationRepository
+func (r *OrganizationRepositoryImpl) FindById(ctx context.Context, level model.OrgLevel, id int) (*model.OrgUnit, error) {
+	table, err := lookupOrgTable(level)
+	if err != nil {
+		return nil, err
+	}
+
+	rawSQL := orgUnitSelect(table) + ` WHERE u.id = $1`
+
+	unit, err := scanOrgUnit(r.Db.QueryRowContext(ctx, rawSQL, id))
+	if err != nil {
+		return nil, err
+	}
+	unit.Level = level
+
+	return unit, nil
+}
+
+// FindAll implements OrganizationRepository
+func (r *OrganizationRepositoryImpl) FindAll(ctx context.Context, query *model.SearchOrgUnitQuery) ([]*model.OrgUnit, error) {
+	table, err := lookupOrgTable(query.Level)
+	if err != nil {
+		return nil, err
+	}
+
+	var rawSQL strings.Builder
+	rawSQL.WriteString(orgUnitSelect(table))
+
+	var whereConditions []string
+	var whereParams []interface{}
+	index := 1
+
+	if query.ParentId > 0 && table.parentColumn != "" {
+		whereConditions = append(whereConditions, "u."+table.parentColumn+" = $"+strconv.Itoa(index))
+		whereParams = append(whereParams, query.ParentId)
+		index++
+	}
+	if query.Name != "" {
+		whereConditions = append(whereConditions, "LOWER(u.name) LIKE $"+strconv.Itoa(index))
+		whereParams = append(whereParams, "%"+strings.ToLower(query.Name)+"%")
+	}
+
+	rawSQL.WriteString(whereClause(whereConditions))
+	rawSQL.WriteString(" ORDER BY u.name, u.id")
+
+	rows, err := r.Db.QueryContext(ctx, rawSQL.String(), whereParams...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	units := []*model.OrgUnit{}
+	for rows.Next() {
+		unit, err := scanOrgUnit(rows)
+		if err != nil {
+			return nil, err
+		}
+		unit.Level = query.Level
+		units = append(units, unit)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return units, nil
+}
+
+// Rollup implements OrganizationRepository. For every unit at the requested
+// level it aggregates the metric over the matching reports.
+func (r *OrganizationRepositoryImpl) Rollup(ctx context.Context, query *model.RollupQuery) ([]*model.RollupRow, error) {
+	table, err := lookupOrgTable(query.Level)
+	if err != nil {
+		return nil, err
+	}
+
+	if query.Metric == model.OfferingsMetric {
+		return r.rollupOfferings(ctx, table, query.Filter)
+	}
+
+	// The metric is interpolated as a column name, so only known keys pass
+	activity, ok := model.FindActivity(query.Metric)
+	if !ok {
+		return nil, fmt.Errorf("unknown metric %q", query.Metric)
+	}
+
+	whereConditions, whereParams := reportConditions(query.Filter)
+
+	rawSQL := `
+		SELECT
+			` + table.alias + `.id,
+			` + table.alias + `.name,
+			COUNT(*),
+			COALESCE(SUM(m.total), 0),
+			COALESCE(AVG(m.average), 0),
+			COALESCE(SUM(m.average), 0),
+			COALESCE(SUM(m.total) / NULLIF(SUM(m.weeks), 0), 0)` + reportFrom + `
+		CROSS JOIN LATERAL (` + weeklyStats("t."+activity.Key) + `) m` +
+		whereClause(whereConditions) + `
+		GROUP BY ` + table.alias + `.id, ` + table.alias + `.name
+		ORDER BY ` + table.alias + `.name`
+
+	rows, err := r.Db.QueryContext(ctx, rawSQL, whereParams...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	result := []*model.RollupRow{}
+	for rows.Next() {
+		var row model.RollupRow
+		if err := rows.Scan(
+			&row.Id,
+			&row.Name,
+			&row.ReportCount,
+			&row.Total,
+			&row.Average,
+			&row.SumOfAverages,
+			&row.AveragePerWeek,
+		); err != nil {
+			return nil, err
+		}
+		result = append(result, &row)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// rollupOfferings totals the tithes and offerings of every unit in cents,
+// one total per currency, the way model.SumOfferings does for a summary
+func (r *OrganizationRepositoryImpl) rollupOfferings(ctx context.Context, table orgTable, filter *model.SearchReportQuery) ([]*model.RollupRow, error) {
+	whereConditions, whereParams := reportConditions(filter)
+
+	rawSQL := `
+		SELECT
+			` + table.alias + `.id,
+			` + table.alias + `.name,
+			t.currency,
+			COUNT(*),
+			COALESCE(SUM(m.total), 0),
+			COALESCE(SUM(m.weeks), 0)` + reportFrom + `
+		CROSS JOIN LATERAL (` + weeklyMoney("t.tithes_and_offerings") + `) m` +
+		whereClause(whereConditions) + `
+		GROUP BY ` + table.alias + `.id, ` + table.alias + `.name, t.currency
+		ORDER BY ` + table.alias + `.name, ` + table.alias + `.id`
+
+	rows, err := r.Db.QueryContext(ctx, rawSQL, whereParams...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	// Rows come one per unit and currency; fold them into one per unit
+	result := []*model.RollupRow{}
+	var row *model.RollupRow
+	for rows.Next() {
+		var (
+			id, reportCount int
+			name, currency  string
+			total           model.Money
+			weeks           int
+		)
+		if err := rows.Scan(&id, &name, &currency, &reportCount, &total, &weeks); err != nil {
+			return nil, err
+		}
+
+		if row == nil || row.Id != id {
+			row = &model.RollupRow{Id: id, Name: name, Offerings: map[string]model.Money{}}
+			result = append(result, row)
+		}
+		row.ReportCount += reportCount
+		if weeks > 0 {
+			row.Offerings[currency] += total
+		}
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// weeklyMoney returns a subquery with the total in cents and the number of
+// weeks recorded in a JSONB array of decimal amounts of a single report
+func weeklyMoney(column string) string {
+	return `
+			SELECT
+				COALESCE(SUM(ROUND(e.v::numeric * 100)), 0)::bigint AS total,
+				COUNT(e.v) AS weeks
+			FROM jsonb_array_elements_text(
+				CASE WHEN jsonb_typeof(` + column + `) = 'array' THEN ` + column + ` ELSE '[]'::jsonb END
+			) AS e(v)
+		`
+}
+
+// weeklyStats returns a subquery with the total, the average and the number
+// of weeks recorded in a JSONB array column of a single report
+func weeklyStats(column string) string {
+	return `
+			SELECT
+				COALESCE(SUM(e.v::numeric), 0) AS total,
+				COALESCE(AVG(e.v::numeric), 0) AS average,
+				COUNT(e.v) AS weeks
+			FROM jsonb_array_elements_text(
+				CASE WHEN jsonb_typeof(` + column + `) = 'array' THEN ` + column + ` ELSE '[]'::jsonb END
+			) AS e(v)
+		`
+}
+
+func orgUnitSelect(table orgTable) string {
+	if table.parentColumn == "" {
+		return `
+		SELECT
+			u.id,
+			0,
+			'',
+			u.name,
+			u.created_at,
+			u.updated_at
+		FROM ` + table.table + ` u`
+	}
+
+	return `
+		SELECT
+			u.id,
+			u.` + table.parentColumn + `,
+			p.name,
+			u.name,
+			u.created_at,
+			u.updated_at
+		FROM ` + table.table + ` u
+		JOIN ` + table.parentTable + ` p ON p.id = u.` + table.parentColumn
+}
+
+func scanOrgUnit(row rowScanner) (*model.OrgUnit, error) {
+	var unit model.OrgUnit
+	err := row.Scan(
+		&unit.Id,
+		&unit.ParentId,
+		&unit.ParentName,
+		&unit.Name,
+		&unit.CreatedAt,
+		&unit.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &unit, nil
+}
@@ -0,0 +1,186 @@
+package repository
+
+import (
+	"reports/model"
+	"strconv"
+	"strings"
+
+	"github.com/lib/pq"
+)
+
+// reportConditions translates the search filters into WHERE conditions over
+// the tables joined in reportFrom. Placeholders are numbered from $1.
+func reportConditions(query *model.SearchReportQuery) ([]string, []interface{}) {
+	var whereConditions []string
+	var whereParams []interface{}
+	index := 1
+
+	add := func(condition string, param interface{}) {
+		whereConditions = append(whereConditions, strings.ReplaceAll(condition, "?", "$"+strconv.Itoa(index)))
+		whereParams = append(whereParams, param)
+		index++
+	}
+
+	// Adding dynamic conditions based on query parameters
+	if !query.MonthOf.IsZero() {
+		add("t.month_of = ?", query.MonthOf)
+	}
+	if !query.MonthFrom.IsZero() {
+		add("t.month_of >= ?", query.MonthFrom)
+	}
+	if !query.MonthTo.IsZero() {
+		add("t.month_of <= ?", query.MonthTo)
+	}
+	if query.Year > 0 {
+		add("EXTRACT(YEAR FROM t.month_of) = ?", query.Year)
+	}
+	if query.WorkerId > 0 {
+		add("t.worker_id = ?", query.WorkerId)
+	}
+	if query.WorkerName != "" {
+		add("LOWER(w.name) LIKE ?", "%"+strings.ToLower(query.WorkerName)+"%")
+	}
+	if query.ChurchId > 0 {
+		add("t.church_id = ?", query.ChurchId)
+	}
+	if query.AreaId > 0 {
+		add("c.area_id = ?", query.AreaId)
+	}
+	if query.DistrictId > 0 {
+		add("a.district_id = ?", query.DistrictId)
+	}
+	if query.RegionId > 0 {
+		add("d.region_id = ?", query.RegionId)
+	}
+	if query.Status != "" {
+		add("t.status = ?", query.Status)
+	}
+	if query.AreaOfAssignment != "" {
+		add("LOWER(a.name) LIKE ?", "%"+strings.ToLower(query.AreaOfAssignment)+"%")
+	}
+	if query.NameOfChurch != "" {
+		add("LOWER(c.name) LIKE ?", "%"+strings.ToLower(query.NameOfChurch)+"%")
+	}
+	if !query.CreatedFrom.IsZero() {
+		add("t.created_at >= ?", query.CreatedFrom)
+	}
+	if !query.CreatedTo.IsZero() {
+		add("t.created_at <= ?", query.CreatedTo)
+	}
+	if !query.UpdatedFrom.IsZero() {
+		add("t.updated_at >= ?", query.UpdatedFrom)
+	}
+	if !query.UpdatedTo.IsZero() {
+		add("t.updated_at <= ?", query.UpdatedTo)
+	}
+	// Keys come from model.Activities, never from input, so the column
+	// names are safe to write into the query
+	for _, activity := range model.Activities {
+		if min, ok := query.AverageMin[activity.Key]; ok {
+			add(activityAverage(activity.Key)+" >= ?", min)
+		}
+		if max, ok := query.AverageMax[activity.Key]; ok {
+			add(activityAverage(activity.Key)+" <= ?", max)
+		}
+	}
+	if query.Q != "" {
+		add("t.search_vector @@ websearch_to_tsquery('"+searchConfig+"', ?)", query.Q)
+	}
+
+	// Deleted reports only show up in the trash
+	if query.Deleted {
+		whereConditions = append(whereConditions, "t.deleted_at IS NOT NULL")
+	} else {
+		whereConditions = append(whereConditions, "t.deleted_at IS NULL")
+	}
+
+	// Restrict to the reports the caller is allowed to see
+	if query.Scope != nil {
+		if query.Scope.Role == model.RoleSupervisor {
+			add("c.area_id = ANY(?)", pq.Array(query.Scope.AreaIds))
+		} else {
+			add("t.worker_id = ?", query.Scope.WorkerId)
+		}
+	}
+
+	return whereConditions, whereParams
+}
+
+// whereClause joins the conditions into a WHERE clause, or returns ""
+func whereClause(conditions []string) string {
+	if len(conditions) == 0 {
+		return ""
+	}
+	return " WHERE " + strings.Join(conditions, " AND ")
+}
+
+// activityAverage is the rounded weekly average of an activity column, as
+// model.CalculateAverage computes it; 0 without weeks
+func activityAverage(key string) string {
+	return "COALESCE((SELECT ROUND(AVG(value::numeric)) FROM jsonb_array_elements_text(" + jsonArray("t."+key) + ")), 0)"
+}
+
+// reportSortColumns maps the fixed keys of model.ReportSortKeys to columns
+// of the tables joined in reportFrom
+var reportSortColumns = map[string]string{
+	"id":                 "t.id",
+	"month_of":           "t.month_of",
+	"worker_name":        "w.name",
+	"area_of_assignment": "a.name",
+	"name_of_church":     "c.name",
+	"status":             "t.status",
+	"created_at":         "t.created_at",
+	"updated_at":         "t.updated_at",
+}
+
+// sortExpression is the SQL value of a key of model.ReportSortKeys
+func sortExpression(key string) string {
+	if column, ok := reportSortColumns[key]; ok {
+		return column
+	}
+	return activityAverage(strings.TrimSuffix(key, "_average"))
+}
+
+// orderByClause builds the ORDER BY clause of the sort, completed with the
+// id so that reports with equal keys keep a stable order across pages.
+// Reversed, it lists the same reports from the end, to page backwards.
+func orderByClause(sort []model.SortField, reversed bool) string {
+	var terms []string
+	for _, field := range model.KeysetSort(sort) {
+		term := sortExpression(field.Key)
+		if field.Desc != reversed {
+			term += " DESC"
+		}
+		terms = append(terms, term)
+	}
+	return " ORDER BY " + strings.Join(terms, ", ")
+}
+
+// keysetCondition selects the reports after the cursor in the order of its
+// sort, or before it for a cursor going back: those with a greater first
+// key, or an equal first key and a greater second key, and so on.
+// Placeholders are numbered from index.
+func keysetCondition(cursor *model.ReportCursor, index int) (string, []interface{}) {
+	keys := model.KeysetSort(cursor.Fields)
+	placeholders := make([]string, len(keys))
+	for i := range keys {
+		placeholders[i] = "$" + strconv.Itoa(index+i)
+	}
+
+	var alternatives []string
+	for i, field := range keys {
+		var terms []string
+		for j := 0; j < i; j++ {
+			terms = append(terms, sortExpression(keys[j].Key)+" = "+placeholders[j])
+		}
+
+		operator := " > "
+		if field.Desc != cursor.Before {
+			operator = " < "
+		}
+		terms = append(terms, sortExpression(field.Key)+operator+placeholders[i])
+		alternatives = append(alternatives, "("+strings.Join(terms, " AND ")+")")
+	}
+
+	return "(" + strings.Join(alternatives, " OR ") + ")", cursor.Params
+}
@@ -3,13 +3,23 @@ package repository
 import (
 	"context"
 	"reports/model"
+	"time"
 )
 
+// ReportRepository stores reports. The revision given to a write, when not
+// nil, is recorded in the same transaction, so a change is never saved
+// without its history; its ReportId is filled in from the report.
 type ReportRepository interface {
-	Save(ctx context.Context, report *model.Report) error
-	Update(ctx context.Context, report *model.Report) error
-	Delete(ctx context.Context, reportId int) error
+	Save(ctx context.Context, report *model.Report, revision *model.ReportRevision) error
+	Update(ctx context.Context, report *model.Report, revision *model.ReportRevision) error
+	UpdateStatus(ctx context.Context, report *model.Report, revision *model.ReportRevision) error
+	Delete(ctx context.Context, report *model.Report, revision *model.ReportRevision) error
+	Restore(ctx context.Context, report *model.Report, revision *model.ReportRevision) error
+	Purge(ctx context.Context, deletedBefore time.Time, revision *model.ReportRevision) ([]int, error)
 	FindById(ctx context.Context, reportId int) (*model.Report, error)
+	FindDeletedById(ctx context.Context, reportId int) (*model.Report, error)
 	FindAll(ctx context.Context, query *model.SearchReportQuery) (*model.SearchReportResult, error)
-	ReportTaken(ctx context.Context, id int, monthOf, workerName string) ([]*model.Report, error)
+	Search(ctx context.Context, query *model.SearchReportQuery) (*model.ReportSearchResult, error)
+	ReportTaken(ctx context.Context, id int, monthOf model.Period, workerId int) ([]*model.Report, error)
+	FindPeople(ctx context.Context, name string, query *model.SearchReportQuery) ([]*model.PersonOccurrence, error)
 }
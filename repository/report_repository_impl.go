@@ -4,12 +4,12 @@ import (
 	"context"
 	"database/sql"
 	"encoding/json"
+	"errors"
 	"reports/helper"
 	"reports/model"
 	"strconv"
 	"strings"
-
-	_ "github.com/lib/pq"
+	"time"
 )
 
 type ReportRepositoryImpl struct {
@@ -20,8 +20,9 @@ func NewReportRepository(Db *sql.DB) ReportRepository {
 	return &ReportRepositoryImpl{Db: Db}
 }
 
-// Delete implements BookRepository
-func (r *ReportRepositoryImpl) Delete(ctx context.Context, reportId int) error {
+// Delete implements ReportRepository. The report is moved to the trash,
+// where it stays until it is restored or purged.
+func (r *ReportRepositoryImpl) Delete(ctx context.Context, report *model.Report, revision *model.ReportRevision) error {
 	tx, err := r.Db.BeginTx(ctx, nil)
 	if err != nil {
 		return err
@@ -29,450 +30,240 @@ func (r *ReportRepositoryImpl) Delete(ctx context.Context, reportId int) error {
 	defer helper.CommitOrRollback(tx)
 
 	rawSQL := `
-		DELETE FROM reports
-		WHERE id = $1
+		UPDATE reports SET
+			deleted_at = $1,
+			deleted_by = NULLIF($2, 0),
+			version = version + 1
+		WHERE id = $3
+		AND deleted_at IS NULL
 	`
 
-	_, err = tx.ExecContext(ctx, rawSQL, reportId)
+	_, err = tx.ExecContext(ctx, rawSQL, report.DeletedAt, report.DeletedBy, report.Id)
 	if err != nil {
 		return err
 	}
 
-	return nil
+	return recordRevision(ctx, tx, revision, report.Id)
 }
 
-// FindAll implements BookRepository
-func (r *ReportRepositoryImpl) FindAll(ctx context.Context, query *model.SearchReportQuery) (*model.SearchReportResult, error) {
+// Restore implements ReportRepository
+func (r *ReportRepositoryImpl) Restore(ctx context.Context, report *model.Report, revision *model.ReportRevision) error {
 	tx, err := r.Db.BeginTx(ctx, nil)
 	if err != nil {
-		return nil, err
+		return err
 	}
 	defer helper.CommitOrRollback(tx)
 
-	var rawSQL strings.Builder
-	rawSQL.WriteString(`
-		SELECT
-			id,
-			month_of,
-			worker_name,
-			area_of_assignment,
-			name_of_church,
-			created_at,
-			updated_at,
-			worship_service,
-			sunday_school,
-			prayer_meetings,
-			bible_studies,
-			mens_fellowships,
-			womens_fellowships,
-			youth_fellowships,
-			child_fellowships,
-			outreach,
-			training_or_seminars,
-			leadership_conferences,
-			leadership_training,
-			others,
-			family_days,
-			tithes_and_offerings,
-			home_visited,
-			bible_study_or_group_led,
-			sermon_or_message_preached,
-			person_newly_contacted,
-			person_followed_up,
-			person_led_to_christ,
-			names,
-			narrative_report,
-			challenges_and_problem_encountered,
-			prayer_request
-		FROM reports t
-	`)
-
-	var whereConditions []string
-	var whereParams []interface{}
-	index := 1
-
-	// Adding dynamic conditions based on query parameters
-	if query.MonthOf != "" {
-		monthOfParam := "%" + strings.ToLower(query.MonthOf) + "%"
-		whereConditions = append(whereConditions, "LOWER(t.month_of) LIKE $"+strconv.Itoa(index))
-		whereParams = append(whereParams, monthOfParam)
-		index++
-	}
-	if query.WorkerName != "" {
-		workerNameParam := "%" + strings.ToLower(query.WorkerName) + "%"
-		whereConditions = append(whereConditions, "LOWER(t.worker_name) LIKE $"+strconv.Itoa(index))
-		whereParams = append(whereParams, workerNameParam)
-		index++
-	}
+	rawSQL := `
+		UPDATE reports SET
+			deleted_at = NULL,
+			deleted_by = NULL,
+			updated_at = $1,
+			version = version + 1
+		WHERE id = $2
+		AND deleted_at IS NOT NULL
+		RETURNING version
+	`
 
-	if len(whereConditions) > 0 {
-		rawSQL.WriteString(" WHERE ")
-		rawSQL.WriteString(strings.Join(whereConditions, " AND "))
+	err = tx.QueryRowContext(ctx, rawSQL, report.UpdatedAt, report.Id).Scan(&report.Version)
+	if err != nil {
+		if isUniqueViolation(err, "reports_worker_month_key") {
+			return ErrReportTaken
+		}
+		return err
 	}
 
-	rawSQL.WriteString(" ORDER BY t.id") // Replace with your desired ordering column
+	return recordRevision(ctx, tx, revision, report.Id)
+}
 
-	// Pagination
-	rawSQL.WriteString(" LIMIT $")
-	rawSQL.WriteString(strconv.Itoa(index))
-	rawSQL.WriteString(" OFFSET $")
-	rawSQL.WriteString(strconv.Itoa(index + 1))
+// Purge implements ReportRepository. Reports deleted before the given time
+// are removed for good; the ids of the removed reports are returned.
+func (r *ReportRepositoryImpl) Purge(ctx context.Context, deletedBefore time.Time, revision *model.ReportRevision) ([]int, error) {
+	tx, err := r.Db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer helper.CommitOrRollback(tx)
 
-	// Append pagination parameters to args slice
-	whereParams = append(whereParams, query.PerPage, (query.Page-1)*query.PerPage)
+	rawSQL := `
+		DELETE FROM reports
+		WHERE deleted_at < $1
+		RETURNING id
+	`
 
-	// Execute query
-	rows, err := tx.QueryContext(ctx, rawSQL.String(), whereParams...)
+	rows, err := tx.QueryContext(ctx, rawSQL, deletedBefore)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
 
-	var reports []*model.Report // Changed to []*model.Report to match SearchReportResult.Reports
-
+	ids := []int{}
 	for rows.Next() {
-		var report model.Report
-		var (
-			worshipServiceJSON        []byte
-			sundaySchoolJSON          []byte
-			prayerMeetingsJSON        []byte
-			bibleStudiesJSON          []byte
-			mensFellowshipsJSON       []byte
-			womensFellowshipsJSON     []byte
-			youthFellowshipsJSON      []byte
-			childFellowshipsJSON      []byte
-			outreachJSON              []byte
-			trainingOrSeminarsJSON    []byte
-			leadershipConferencesJSON []byte
-			leadershipTrainingJSON    []byte
-			othersJSON                []byte
-			familyDaysJSON            []byte
-			tithesAndOfferingsJSON    []byte
-			homeVisitedJSON           []byte
-			bibleStudyOrGroupLedJSON  []byte
-			sermonOrMessageJSON       []byte
-			personNewlyContactedJSON  []byte
-			personFollowedUpJSON      []byte
-			personLedToChristJSON     []byte
-			namesJSON                 []byte
-		)
-
-		// Scan row into variables
-		err := rows.Scan(
-			&report.Id,
-			&report.MonthOf,
-			&report.WorkerName,
-			&report.AreaOfAssignment,
-			&report.NameOfChurch,
-			&report.CreatedAt,
-			&report.UpdatedAt,
-			&worshipServiceJSON,
-			&sundaySchoolJSON,
-			&prayerMeetingsJSON,
-			&bibleStudiesJSON,
-			&mensFellowshipsJSON,
-			&womensFellowshipsJSON,
-			&youthFellowshipsJSON,
-			&childFellowshipsJSON,
-			&outreachJSON,
-			&trainingOrSeminarsJSON,
-			&leadershipConferencesJSON,
-			&leadershipTrainingJSON,
-			&othersJSON,
-			&familyDaysJSON,
-			&tithesAndOfferingsJSON,
-			&homeVisitedJSON,
-			&bibleStudyOrGroupLedJSON,
-			&sermonOrMessageJSON,
-			&personNewlyContactedJSON,
-			&personFollowedUpJSON,
-			&personLedToChristJSON,
-			&namesJSON,
-			&report.NarrativeReport,
-			&report.ChallengesAndProblemEncountered,
-			&report.PrayerRequest,
-		)
-		if err != nil {
+		var id int
+		if err := rows.Scan(&id); err != nil {
 			return nil, err
 		}
-
-		// Unmarshal JSONB fields into respective slices or fields
-		if worshipServiceJSON != nil {
-			if err := json.Unmarshal(worshipServiceJSON, &report.WorshipService); err != nil {
-				return nil, err
-			}
-		}
-		// Repeat for other JSONB fields...
-
-		reports = append(reports, &report) // Append pointer to report
+		ids = append(ids, id)
 	}
 
-	// Check for any error during iteration
 	if err := rows.Err(); err != nil {
 		return nil, err
 	}
+	rows.Close()
 
-	// Construct the result object
-	result := &model.SearchReportResult{
-		TotalCount: len(reports), // Assuming you want total count of items fetched
-		Reports:    reports,
-		Page:       query.Page,
-		PerPage:    query.PerPage,
+	// One revision per purged report, from the template
+	if revision != nil {
+		for _, id := range ids {
+			purged := *revision
+			if err := recordRevision(ctx, tx, &purged, id); err != nil {
+				return nil, err
+			}
+		}
 	}
 
-	return result, nil
+	return ids, nil
 }
 
-// FindById implements BookRepository
-func (r *ReportRepositoryImpl) FindById(ctx context.Context, id int) (*model.Report, error) {
+// FindAll implements ReportRepository
+func (r *ReportRepositoryImpl) FindAll(ctx context.Context, query *model.SearchReportQuery) (*model.SearchReportResult, error) {
 	tx, err := r.Db.BeginTx(ctx, nil)
 	if err != nil {
 		return nil, err
 	}
 	defer helper.CommitOrRollback(tx)
 
-	// Construct the dynamic SELECT statement based on non-zero fields
-	rawSQL := `
-		SELECT 
-			id,
-			month_of,
-			worker_name,
-			area_of_assignment,
-			name_of_church,
-			created_at,
-			updated_at,
-			worship_service,
-			sunday_school,
-			prayer_meetings,
-			bible_studies,
-			mens_fellowships,
-			womens_fellowships,
-			youth_fellowships,
-			child_fellowships,
-			outreach,
-			training_or_seminars,
-			leadership_conferences,
-			leadership_training,
-			others,
-			family_days,
-			tithes_and_offerings,
-			home_visited,
-			bible_study_or_group_led,
-			sermon_or_message_preached,
-			person_newly_contacted,
-			person_followed_up,
-			person_led_to_christ,
-			names,
-			narrative_report,
-			challenges_and_problem_encountered,
-			prayer_request
-		FROM reports
-		WHERE id = $1
-	`
-
-	var report model.Report
-	var (
-		worshipServiceJSON        []byte
-		sundaySchoolJSON          []byte
-		prayerMeetingsJSON        []byte
-		bibleStudiesJSON          []byte
-		mensFellowshipsJSON       []byte
-		womensFellowshipsJSON     []byte
-		youthFellowshipsJSON      []byte
-		childFellowshipsJSON      []byte
-		outreachJSON              []byte
-		trainingOrSeminarsJSON    []byte
-		leadershipConferencesJSON []byte
-		leadershipTrainingJSON    []byte
-		othersJSON                []byte
-		familyDaysJSON            []byte
-		tithesAndOfferingsJSON    []byte
-		homeVisitedJSON           []byte
-		bibleStudyOrGroupLedJSON  []byte
-		sermonOrMessageJSON       []byte
-		personNewlyContactedJSON  []byte
-		personFollowedUpJSON      []byte
-		personLedToChristJSON     []byte
-		namesJSON                 []byte
-	)
-
-	// Execute the query
-	err = tx.QueryRowContext(ctx, rawSQL, id).Scan(
-		&report.Id,
-		&report.MonthOf,
-		&report.WorkerName,
-		&report.AreaOfAssignment,
-		&report.NameOfChurch,
-		&report.CreatedAt,
-		&report.UpdatedAt,
-		&worshipServiceJSON,
-		&sundaySchoolJSON,
-		&prayerMeetingsJSON,
-		&bibleStudiesJSON,
-		&mensFellowshipsJSON,
-		&womensFellowshipsJSON,
-		&youthFellowshipsJSON,
-		&childFellowshipsJSON,
-		&outreachJSON,
-		&trainingOrSeminarsJSON,
-		&leadershipConferencesJSON,
-		&leadershipTrainingJSON,
-		&othersJSON,
-		&familyDaysJSON,
-		&tithesAndOfferingsJSON,
-		&homeVisitedJSON,
-		&bibleStudyOrGroupLedJSON,
-		&sermonOrMessageJSON,
-		&personNewlyContactedJSON,
-		&personFollowedUpJSON,
-		&personLedToChristJSON,
-		&namesJSON,
-		&report.NarrativeReport,
-		&report.ChallengesAndProblemEncountered,
-		&report.PrayerRequest,
-	)
-	if err != nil {
-		return nil, err
-	}
-
-	// Unmarshal JSONB fields into their respective slices
-	if worshipServiceJSON != nil {
-		if err := json.Unmarshal(worshipServiceJSON, &report.WorshipService); err != nil {
-			return nil, err
-		}
-	}
+	whereConditions, whereParams := reportConditions(query)
 
-	if sundaySchoolJSON != nil {
-		if err := json.Unmarshal(sundaySchoolJSON, &report.SundaySchool); err != nil {
-			return nil, err
-		}
-	}
-
-	if prayerMeetingsJSON != nil {
-		if err := json.Unmarshal(prayerMeetingsJSON, &report.PrayerMeetings); err != nil {
-			return nil, err
-		}
-	}
-
-	if bibleStudiesJSON != nil {
-		if err := json.Unmarshal(bibleStudiesJSON, &report.BibleStudies); err != nil {
-			return nil, err
-		}
+	result := &model.SearchReportResult{
+		Reports: []*model.Report{},
+		Page:    query.Page,
+		PerPage: query.PerPage,
 	}
 
-	if mensFellowshipsJSON != nil {
-		if err := json.Unmarshal(mensFellowshipsJSON, &report.MensFellowships); err != nil {
+	// Count every match, whatever page this is
+	if !query.SkipTotal {
+		var total int
+		countSQL := "SELECT COUNT(*)" + reportFrom + whereClause(whereConditions)
+		if err := tx.QueryRowContext(ctx, countSQL, whereParams...).Scan(&total); err != nil {
 			return nil, err
 		}
+		result.TotalCount = &total
 	}
 
-	if womensFellowshipsJSON != nil {
-		if err := json.Unmarshal(womensFellowshipsJSON, &report.WomensFellowships); err != nil {
-			return nil, err
-		}
-	}
+	sort := query.Sort
+	backward := false
+	if query.Cursor != nil {
+		sort = query.Cursor.Fields
+		backward = query.Cursor.Before
+		result.Page = 0
 
-	if youthFellowshipsJSON != nil {
-		if err := json.Unmarshal(youthFellowshipsJSON, &report.YouthFellowships); err != nil {
-			return nil, err
-		}
+		condition, params := keysetCondition(query.Cursor, len(whereParams)+1)
+		whereConditions = append(whereConditions, condition)
+		whereParams = append(whereParams, params...)
 	}
+	index := len(whereParams) + 1
 
-	if childFellowshipsJSON != nil {
-		if err := json.Unmarshal(childFellowshipsJSON, &report.ChildFellowships); err != nil {
-			return nil, err
+	var rawSQL strings.Builder
+	rawSQL.WriteString(`
+		SELECT` + reportColumns + reportFrom)
+	rawSQL.WriteString(whereClause(whereConditions))
+	rawSQL.WriteString(orderByClause(sort, backward))
+
+	// Pagination; PerPage 0 returns every matching report. One report more
+	// than asked tells whether there is another page.
+	if query.PerPage > 0 {
+		rawSQL.WriteString(" LIMIT $")
+		rawSQL.WriteString(strconv.Itoa(index))
+		whereParams = append(whereParams, query.PerPage+1)
+
+		if query.Cursor == nil {
+			rawSQL.WriteString(" OFFSET $")
+			rawSQL.WriteString(strconv.Itoa(index + 1))
+			whereParams = append(whereParams, (query.Page-1)*query.PerPage)
 		}
 	}
 
-	if outreachJSON != nil {
-		if err := json.Unmarshal(outreachJSON, &report.Outreach); err != nil {
-			return nil, err
-		}
+	// Execute query
+	rows, err := tx.QueryContext(ctx, rawSQL.String(), whereParams...)
+	if err != nil {
+		return nil, err
 	}
+	defer rows.Close()
 
-	if trainingOrSeminarsJSON != nil {
-		if err := json.Unmarshal(trainingOrSeminarsJSON, &report.TrainingOrSeminars); err != nil {
+	for rows.Next() {
+		report, err := scanReport(rows)
+		if err != nil {
 			return nil, err
 		}
-	}
 
-	if leadershipConferencesJSON != nil {
-		if err := json.Unmarshal(leadershipConferencesJSON, &report.LeadershipConferences); err != nil {
-			return nil, err
-		}
+		result.Reports = append(result.Reports, report)
 	}
 
-	if leadershipTrainingJSON != nil {
-		if err := json.Unmarshal(leadershipTrainingJSON, &report.LeadershipTraining); err != nil {
-			return nil, err
-		}
+	// Check for any error during iteration
+	if err := rows.Err(); err != nil {
+		return nil, err
 	}
 
-	if othersJSON != nil {
-		if err := json.Unmarshal(othersJSON, &report.Others); err != nil {
-			return nil, err
-		}
+	more := query.PerPage > 0 && len(result.Reports) > query.PerPage
+	if more {
+		result.Reports = result.Reports[:query.PerPage]
 	}
-
-	if familyDaysJSON != nil {
-		if err := json.Unmarshal(familyDaysJSON, &report.FamilyDays); err != nil {
-			return nil, err
+	if backward {
+		for i, j := 0, len(result.Reports)-1; i < j; i, j = i+1, j-1 {
+			result.Reports[i], result.Reports[j] = result.Reports[j], result.Reports[i]
 		}
 	}
 
-	if tithesAndOfferingsJSON != nil {
-		if err := json.Unmarshal(tithesAndOfferingsJSON, &report.TithesAndOfferings); err != nil {
-			return nil, err
+	// Going forward there is a next page when more reports came back, and a
+	// previous one unless this is the first; going back, the other way round
+	if len(result.Reports) > 0 && query.PerPage > 0 {
+		hasNext, hasPrev := more, query.Cursor != nil || query.Page > 1
+		if backward {
+			hasNext, hasPrev = true, more
 		}
-	}
 
-	if homeVisitedJSON != nil {
-		if err := json.Unmarshal(homeVisitedJSON, &report.HomeVisited); err != nil {
-			return nil, err
+		if hasNext {
+			result.NextCursor = model.NewReportCursor(sort, result.Reports[len(result.Reports)-1], false).Encode()
 		}
-	}
-
-	if bibleStudyOrGroupLedJSON != nil {
-		if err := json.Unmarshal(bibleStudyOrGroupLedJSON, &report.BibleStudyOrGroupLed); err != nil {
-			return nil, err
+		if hasPrev {
+			result.PrevCursor = model.NewReportCursor(sort, result.Reports[0], true).Encode()
 		}
 	}
 
-	if sermonOrMessageJSON != nil {
-		if err := json.Unmarshal(sermonOrMessageJSON, &report.SermonOrMessagePreached); err != nil {
-			return nil, err
-		}
-	}
+	return result, nil
+}
 
-	if personNewlyContactedJSON != nil {
-		if err := json.Unmarshal(personNewlyContactedJSON, &report.PersonNewlyContacted); err != nil {
-			return nil, err
-		}
+// FindById implements ReportRepository
+func (r *ReportRepositoryImpl) FindById(ctx context.Context, id int) (*model.Report, error) {
+	tx, err := r.Db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
 	}
+	defer helper.CommitOrRollback(tx)
 
-	if personFollowedUpJSON != nil {
-		if err := json.Unmarshal(personFollowedUpJSON, &report.PersonFollowedUp); err != nil {
-			return nil, err
-		}
-	}
+	rawSQL := `
+		SELECT` + reportColumns + reportFrom + `
+		WHERE t.id = $1
+		AND t.deleted_at IS NULL
+	`
 
-	if personLedToChristJSON != nil {
-		if err := json.Unmarshal(personLedToChristJSON, &report.PersonLedToChrist); err != nil {
-			return nil, err
-		}
-	}
+	return scanReport(tx.QueryRowContext(ctx, rawSQL, id))
+}
 
-	if namesJSON != nil {
-		if err := json.Unmarshal(namesJSON, &report.Names); err != nil {
-			return nil, err
-		}
-	}
+// FindDeletedById implements ReportRepository. It only finds reports that
+// are in the trash.
+func (r *ReportRepositoryImpl) FindDeletedById(ctx context.Context, id int) (*model.Report, error) {
+	rawSQL := `
+		SELECT` + reportColumns + reportFrom + `
+		WHERE t.id = $1
+		AND t.deleted_at IS NOT NULL
+	`
 
-	return &report, nil
+	return scanReport(r.Db.QueryRowContext(ctx, rawSQL, id))
 }
 
-// Save implements BookRepository
-func (r *ReportRepositoryImpl) Save(ctx context.Context, report *model.Report) error {
+// Save implements ReportRepository
+func (r *ReportRepositoryImpl) Save(ctx context.Context, report *model.Report, revision *model.ReportRevision) error {
 	tx, err := r.Db.Begin()
 	if err != nil {
 		return err
@@ -585,7 +376,7 @@ func (r *ReportRepositoryImpl) Save(ctx context.Context, report *model.Report) e
 		return err
 	}
 
-	namesJSON, err := json.Marshal(report.Names)
+	peopleJSON, err := json.Marshal(report.People)
 	if err != nil {
 		return err
 	}
@@ -593,9 +384,8 @@ func (r *ReportRepositoryImpl) Save(ctx context.Context, report *model.Report) e
 	rawSQL := `
 		INSERT INTO reports (
 			month_of,
-			worker_name,
-			area_of_assignment,
-			name_of_church,
+			worker_id,
+			church_id,
 			worship_service,
 			sunday_school,
 			prayer_meetings,
@@ -617,20 +407,22 @@ func (r *ReportRepositoryImpl) Save(ctx context.Context, report *model.Report) e
 			person_newly_contacted,
 			person_followed_up,
 			person_led_to_christ,
-			names,
+			people,
 			narrative_report,
 			challenges_and_problem_encountered,
 			prayer_request,
+			status,
 			created_at,
-			updated_at
-		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20, $21, $22, $23, $24, $25, $26, $27, $28, $29, $30, $31)
+			updated_at,
+			currency
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20, $21, $22, $23, $24, $25, $26, $27, $28, $29, $30, $31, $32)
+		RETURNING id, version
 	`
 
-	_, err = tx.ExecContext(ctx, rawSQL,
+	err = tx.QueryRowContext(ctx, rawSQL,
 		report.MonthOf,
-		report.WorkerName,
-		report.AreaOfAssignment,
-		report.NameOfChurch,
+		report.WorkerId,
+		report.ChurchId,
 		worshipServiceJSON,
 		sundaySchoolJSON,
 		prayerMeetingsJSON,
@@ -652,22 +444,27 @@ func (r *ReportRepositoryImpl) Save(ctx context.Context, report *model.Report) e
 		personNewlyContactedJSON,
 		personFollowedUpJSON,
 		personLedToChristJSON,
-		namesJSON,
+		peopleJSON,
 		report.NarrativeReport,
 		report.ChallengesAndProblemEncountered,
 		report.PrayerRequest,
+		report.Status,
 		report.CreatedAt,
 		report.UpdatedAt,
-	)
+		report.Currency,
+	).Scan(&report.Id, &report.Version)
 	if err != nil {
+		if isUniqueViolation(err, "reports_worker_month_key") {
+			return ErrReportTaken
+		}
 		return err
 	}
 
-	return nil
+	return recordRevision(ctx, tx, revision, report.Id)
 }
 
-// Update implements BookRepository
-func (r *ReportRepositoryImpl) Update(ctx context.Context, report *model.Report) error {
+// Update implements ReportRepository
+func (r *ReportRepositoryImpl) Update(ctx context.Context, report *model.Report, revision *model.ReportRevision) error {
 	tx, err := r.Db.Begin()
 	if err != nil {
 		return err
@@ -677,37 +474,40 @@ func (r *ReportRepositoryImpl) Update(ctx context.Context, report *model.Report)
 	rawSQL := `
         UPDATE reports SET
             month_of = $1,
-            worker_name = $2,
-            area_of_assignment = $3,
-            name_of_church = $4,
-            worship_service = $5,
-            sunday_school = $6,
-            prayer_meetings = $7,
-            bible_studies = $8,
-            mens_fellowships = $9,
-            womens_fellowships = $10,
-            youth_fellowships = $11,
-            child_fellowships = $12,
-            outreach = $13,
-            training_or_seminars = $14,
-            leadership_conferences = $15,
-            leadership_training = $16,
-            others = $17,
-            family_days = $18,
-            tithes_and_offerings = $19,
-            home_visited = $20,
-            bible_study_or_group_led = $21,
-            sermon_or_message_preached = $22,
-            person_newly_contacted = $23,
-            person_followed_up = $24,
-            person_led_to_christ = $25,
-            names = $26,
-            narrative_report = $27,
-            challenges_and_problem_encountered = $28,
-            prayer_request = $29,
-            updated_at = $30
+            worker_id = $2,
+            church_id = $3,
+            worship_service = $4,
+            sunday_school = $5,
+            prayer_meetings = $6,
+            bible_studies = $7,
+            mens_fellowships = $8,
+            womens_fellowships = $9,
+            youth_fellowships = $10,
+            child_fellowships = $11,
+            outreach = $12,
+            training_or_seminars = $13,
+            leadership_conferences = $14,
+            leadership_training = $15,
+            others = $16,
+            family_days = $17,
+            tithes_and_offerings = $18,
+            home_visited = $19,
+            bible_study_or_group_led = $20,
+            sermon_or_message_preached = $21,
+            person_newly_contacted = $22,
+            person_followed_up = $23,
+            person_led_to_christ = $24,
+            people = $25,
+            narrative_report = $26,
+            challenges_and_problem_encountered = $27,
+            prayer_request = $28,
+            updated_at = $29,
+            currency = $32,
+            version = version + 1
         WHERE 
-            id = $32
+            id = $30
+            AND version = $31
+        RETURNING version
     `
 
 	// Marshal arrays to JSON
@@ -795,17 +595,16 @@ func (r *ReportRepositoryImpl) Update(ctx context.Context, report *model.Report)
 	if err != nil {
 		return err
 	}
-	namesJSON, err := json.Marshal(report.Names)
+	peopleJSON, err := json.Marshal(report.People)
 	if err != nil {
 		return err
 	}
 
 	// Execute the update query
-	_, err = tx.ExecContext(ctx, rawSQL,
+	err = tx.QueryRowContext(ctx, rawSQL,
 		report.MonthOf,
-		report.WorkerName,
-		report.AreaOfAssignment,
-		report.NameOfChurch,
+		report.WorkerId,
+		report.ChurchId,
 		worshipServiceJSON,
 		sundaySchoolJSON,
 		prayerMeetingsJSON,
@@ -827,34 +626,91 @@ func (r *ReportRepositoryImpl) Update(ctx context.Context, report *model.Report)
 		personNewlyContactedJSON,
 		personFollowedUpJSON,
 		personLedToChristJSON,
-		namesJSON,
+		peopleJSON,
 		report.NarrativeReport,
 		report.ChallengesAndProblemEncountered,
 		report.PrayerRequest,
 		report.UpdatedAt,
 		report.Id,
-	)
+		report.Version,
+		report.Currency,
+	).Scan(&report.Version)
 	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return ErrReportModified
+		}
+		if isUniqueViolation(err, "reports_worker_month_key") {
+			return ErrReportTaken
+		}
+		return err
+	}
+
+	return recordRevision(ctx, tx, revision, report.Id)
+}
+
+// UpdateStatus implements ReportRepository. It only writes the workflow
+// columns, so a review never overwrites the worker's figures.
+func (r *ReportRepositoryImpl) UpdateStatus(ctx context.Context, report *model.Report, revision *model.ReportRevision) error {
+	tx, err := r.Db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer helper.CommitOrRollback(tx)
+
+	rawSQL := `
+		UPDATE reports SET
+			status = $1,
+			review_note = $2,
+			submitted_at = $3,
+			reviewed_at = $4,
+			reviewed_by = NULLIF($5, 0),
+			updated_at = $6,
+			version = version + 1
+		WHERE id = $7
+		AND version = $8
+		RETURNING version
+	`
+
+	err = tx.QueryRowContext(ctx, rawSQL,
+		report.Status,
+		report.ReviewNote,
+		report.SubmittedAt,
+		report.ReviewedAt,
+		report.ReviewedBy,
+		report.UpdatedAt,
+		report.Id,
+		report.Version,
+	).Scan(&report.Version)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return ErrReportModified
+		}
 		return err
 	}
 
-	return nil
+	return recordRevision(ctx, tx, revision, report.Id)
 }
 
-func (r *ReportRepositoryImpl) ReportTaken(ctx context.Context, id int, monthOf, workerName string) ([]*model.Report, error) {
+// ReportTaken returns the reports, other than the one with the given id, that
+// already cover the worker and month
+func (r *ReportRepositoryImpl) ReportTaken(ctx context.Context, id int, monthOf model.Period, workerId int) ([]*model.Report, error) {
 	var reports []*model.Report
 
 	rawSQL := `
 		SELECT
-			id, 
-			month_of,
-			worker_name
-		FROM reports
-		WHERE month_of = $1 OR
-		worker_name = $2
+			t.id,
+			t.month_of,
+			t.worker_id,
+			w.name
+		FROM reports t
+		JOIN workers w ON w.id = t.worker_id
+		WHERE t.month_of = $1
+		AND t.worker_id = $2
+		AND t.id <> $3
+		AND t.deleted_at IS NULL
 	`
 
-	rows, err := r.Db.QueryContext(ctx, rawSQL, monthOf, workerName)
+	rows, err := r.Db.QueryContext(ctx, rawSQL, monthOf, workerId, id)
 	if err != nil {
 		return nil, err
 	}
@@ -865,6 +721,7 @@ func (r *ReportRepositoryImpl) ReportTaken(ctx context.Context, id int, monthOf,
 		if err := rows.Scan(
 			&report.Id,
 			&report.MonthOf,
+			&report.WorkerId,
 			&report.WorkerName,
 		); err != nil {
 			return nil, err
@@ -872,5 +729,63 @@ func (r *ReportRepositoryImpl) ReportTaken(ctx context.Context, id int, monthOf,
 		reports = append(reports, &report)
 	}
 
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
 	return reports, nil
 }
+
+// FindPeople lists the entries naming the person across the reports
+// matching the query, oldest month first. Names are compared ignoring case
+// and repeated spaces; name is expected in that normalized form.
+func (r *ReportRepositoryImpl) FindPeople(ctx context.Context, name string, query *model.SearchReportQuery) ([]*model.PersonOccurrence, error) {
+	whereConditions, whereParams := reportConditions(query)
+	whereConditions = append(whereConditions,
+		"LOWER(regexp_replace(TRIM(p.value->>'name'), '\\s+', ' ', 'g')) = $"+strconv.Itoa(len(whereParams)+1))
+	whereParams = append(whereParams, name)
+
+	rawSQL := `
+		SELECT
+			t.id,
+			t.month_of,
+			t.worker_id,
+			w.name,
+			c.name,
+			p.value` + reportFrom + `
+		CROSS JOIN LATERAL jsonb_array_elements(` + jsonArray("t.people") + `) p` +
+		whereClause(whereConditions) + `
+		ORDER BY t.month_of, t.id`
+
+	rows, err := r.Db.QueryContext(ctx, rawSQL, whereParams...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	occurrences := []*model.PersonOccurrence{}
+	for rows.Next() {
+		var occurrence model.PersonOccurrence
+		var personJSON []byte
+		if err := rows.Scan(
+			&occurrence.ReportId,
+			&occurrence.MonthOf,
+			&occurrence.WorkerId,
+			&occurrence.WorkerName,
+			&occurrence.NameOfChurch,
+			&personJSON,
+		); err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal(personJSON, &occurrence.Person); err != nil {
+			return nil, err
+		}
+		occurrences = append(occurrences, &occurrence)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return occurrences, nil
+}
@@ -0,0 +1,193 @@
+package repository
+
+import (
+	"encoding/json"
+	"reports/model"
+)
+
+// reportColumns lists the columns read by scanReport, in order. Queries
+// select them from reports aliased as t joined to workers aliased as w.
+const reportColumns = `
+			t.id,
+			t.month_of,
+			t.worker_id,
+			w.name,
+			t.church_id,
+			c.area_id,
+			a.name,
+			c.name,
+			t.created_at,
+			t.updated_at,
+			t.worship_service,
+			t.sunday_school,
+			t.prayer_meetings,
+			t.bible_studies,
+			t.mens_fellowships,
+			t.womens_fellowships,
+			t.youth_fellowships,
+			t.child_fellowships,
+			t.outreach,
+			t.training_or_seminars,
+			t.leadership_conferences,
+			t.leadership_training,
+			t.others,
+			t.family_days,
+			t.tithes_and_offerings,
+			t.currency,
+			t.home_visited,
+			t.bible_study_or_group_led,
+			t.sermon_or_message_preached,
+			t.person_newly_contacted,
+			t.person_followed_up,
+			t.person_led_to_christ,
+			t.people,
+			t.narrative_report,
+			t.challenges_and_problem_encountered,
+			t.prayer_request,
+			t.status,
+			t.review_note,
+			t.submitted_at,
+			t.reviewed_at,
+			COALESCE(t.reviewed_by, 0),
+			t.deleted_at,
+			COALESCE(t.deleted_by, 0),
+			t.version`
+
+// reportFrom is the FROM clause matching reportColumns. It joins the whole
+// church hierarchy so filters can reach any level.
+const reportFrom = `
+		FROM reports t
+		JOIN workers w ON w.id = t.worker_id
+		JOIN churches c ON c.id = t.church_id
+		JOIN areas a ON a.id = c.area_id
+		JOIN districts d ON d.id = a.district_id
+		JOIN regions r ON r.id = d.region_id`
+
+// jsonArray guards a JSONB array column for jsonb_array_elements: reports
+// saved without a value hold SQL or JSON null there
+func jsonArray(column string) string {
+	return "CASE WHEN jsonb_typeof(" + column + ") = 'array' THEN " + column + " ELSE '[]' END"
+}
+
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+// scanReport reads one row selected with reportColumns
+func scanReport(row rowScanner) (*model.Report, error) {
+	var report model.Report
+	var (
+		worshipServiceJSON        []byte
+		sundaySchoolJSON          []byte
+		prayerMeetingsJSON        []byte
+		bibleStudiesJSON          []byte
+		mensFellowshipsJSON       []byte
+		womensFellowshipsJSON     []byte
+		youthFellowshipsJSON      []byte
+		childFellowshipsJSON      []byte
+		outreachJSON              []byte
+		trainingOrSeminarsJSON    []byte
+		leadershipConferencesJSON []byte
+		leadershipTrainingJSON    []byte
+		othersJSON                []byte
+		familyDaysJSON            []byte
+		tithesAndOfferingsJSON    []byte
+		homeVisitedJSON           []byte
+		bibleStudyOrGroupLedJSON  []byte
+		sermonOrMessageJSON       []byte
+		personNewlyContactedJSON  []byte
+		personFollowedUpJSON      []byte
+		personLedToChristJSON     []byte
+		peopleJSON                []byte
+	)
+
+	err := row.Scan(
+		&report.Id,
+		&report.MonthOf,
+		&report.WorkerId,
+		&report.WorkerName,
+		&report.ChurchId,
+		&report.AreaId,
+		&report.AreaOfAssignment,
+		&report.NameOfChurch,
+		&report.CreatedAt,
+		&report.UpdatedAt,
+		&worshipServiceJSON,
+		&sundaySchoolJSON,
+		&prayerMeetingsJSON,
+		&bibleStudiesJSON,
+		&mensFellowshipsJSON,
+		&womensFellowshipsJSON,
+		&youthFellowshipsJSON,
+		&childFellowshipsJSON,
+		&outreachJSON,
+		&trainingOrSeminarsJSON,
+		&leadershipConferencesJSON,
+		&leadershipTrainingJSON,
+		&othersJSON,
+		&familyDaysJSON,
+		&tithesAndOfferingsJSON,
+		&report.Currency,
+		&homeVisitedJSON,
+		&bibleStudyOrGroupLedJSON,
+		&sermonOrMessageJSON,
+		&personNewlyContactedJSON,
+		&personFollowedUpJSON,
+		&personLedToChristJSON,
+		&peopleJSON,
+		&report.NarrativeReport,
+		&report.ChallengesAndProblemEncountered,
+		&report.PrayerRequest,
+		&report.Status,
+		&report.ReviewNote,
+		&report.SubmittedAt,
+		&report.ReviewedAt,
+		&report.ReviewedBy,
+		&report.DeletedAt,
+		&report.DeletedBy,
+		&report.Version,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	// Unmarshal JSONB fields into their respective slices
+	fields := []struct {
+		data []byte
+		dst  interface{}
+	}{
+		{worshipServiceJSON, &report.WorshipService},
+		{sundaySchoolJSON, &report.SundaySchool},
+		{prayerMeetingsJSON, &report.PrayerMeetings},
+		{bibleStudiesJSON, &report.BibleStudies},
+		{mensFellowshipsJSON, &report.MensFellowships},
+		{womensFellowshipsJSON, &report.WomensFellowships},
+		{youthFellowshipsJSON, &report.YouthFellowships},
+		{childFellowshipsJSON, &report.ChildFellowships},
+		{outreachJSON, &report.Outreach},
+		{trainingOrSeminarsJSON, &report.TrainingOrSeminars},
+		{leadershipConferencesJSON, &report.LeadershipConferences},
+		{leadershipTrainingJSON, &report.LeadershipTraining},
+		{othersJSON, &report.Others},
+		{familyDaysJSON, &report.FamilyDays},
+		{tithesAndOfferingsJSON, &report.TithesAndOfferings},
+		{homeVisitedJSON, &report.HomeVisited},
+		{bibleStudyOrGroupLedJSON, &report.BibleStudyOrGroupLed},
+		{sermonOrMessageJSON, &report.SermonOrMessagePreached},
+		{personNewlyContactedJSON, &report.PersonNewlyContacted},
+		{personFollowedUpJSON, &report.PersonFollowedUp},
+		{personLedToChristJSON, &report.PersonLedToChrist},
+		{peopleJSON, &report.People},
+	}
+
+	for _, field := range fields {
+		if field.data == nil {
+			continue
+		}
+		if err := json.Unmarshal(field.data, field.dst); err != nil {
+			return nil, err
+		}
+	}
+
+	return &report, nil
+}
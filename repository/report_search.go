@@ -0,0 +1,135 @@
+package repository
+
+import (
+	"context"
+	"html"
+	"reports/helper"
+	"reports/model"
+	"strconv"
+	"strings"
+)
+
+// searchConfig is the text search configuration of reports.search_vector
+const searchConfig = "english"
+
+// Markers put around matching words by ts_headline. They cannot occur in
+// report text, so the passage can be escaped before they become <mark>.
+const (
+	headlineStart = "\x02"
+	headlineStop  = "\x03"
+)
+
+// searchFields are the text columns covered by search_vector, by JSON name
+var searchFields = []struct {
+	key    string
+	column string
+}{
+	{"narrative_report", "t.narrative_report"},
+	{"challenges_and_problem_encountered", "t.challenges_and_problem_encountered"},
+	{"prayer_request", "t.prayer_request"},
+}
+
+// Search ranks the reports matching query.Q, which uses web search syntax:
+// quoted phrases, "or" and a leading "-" to exclude a word. The other
+// filters of the query apply as in FindAll.
+func (r *ReportRepositoryImpl) Search(ctx context.Context, query *model.SearchReportQuery) (*model.ReportSearchResult, error) {
+	tx, err := r.Db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer helper.CommitOrRollback(tx)
+
+	whereConditions, whereParams := reportConditions(query)
+
+	result := &model.ReportSearchResult{Hits: []*model.ReportSearchHit{}, Page: query.Page, PerPage: query.PerPage}
+
+	// Count every match, whatever page this is
+	countSQL := "SELECT COUNT(*)" + reportFrom + whereClause(whereConditions)
+	if err := tx.QueryRowContext(ctx, countSQL, whereParams...).Scan(&result.TotalCount); err != nil {
+		return nil, err
+	}
+
+	whereParams = append(whereParams, query.Q)
+	tsquery := "websearch_to_tsquery('" + searchConfig + "', $" + strconv.Itoa(len(whereParams)) + ")"
+
+	var rawSQL strings.Builder
+	rawSQL.WriteString(`
+		SELECT
+			t.id,
+			t.month_of,
+			t.worker_id,
+			w.name,
+			a.name,
+			c.name,
+			t.status,
+			ts_rank(t.search_vector, ` + tsquery + `),`)
+	for i, field := range searchFields {
+		// ts_headline falls back to the start of the text when the field
+		// itself does not match, so only fields that match get a passage
+		document := "coalesce(" + field.column + ", '')"
+		rawSQL.WriteString(`
+			CASE WHEN to_tsvector('` + searchConfig + `', ` + document + `) @@ ` + tsquery + `
+				THEN ts_headline('` + searchConfig + `', ` + document + `, ` + tsquery + `,
+					'StartSel=` + headlineStart + `, StopSel=` + headlineStop + `, MaxWords=30, MinWords=10, MaxFragments=2')
+			END`)
+		if i < len(searchFields)-1 {
+			rawSQL.WriteString(",")
+		}
+	}
+	rawSQL.WriteString(reportFrom + whereClause(whereConditions) + `
+		ORDER BY 8 DESC, t.month_of DESC, t.id`)
+
+	// Pagination; PerPage 0 returns every match
+	if query.PerPage > 0 {
+		index := len(whereParams) + 1
+		rawSQL.WriteString(" LIMIT $" + strconv.Itoa(index) + " OFFSET $" + strconv.Itoa(index+1))
+		whereParams = append(whereParams, query.PerPage, (query.Page-1)*query.PerPage)
+	}
+
+	rows, err := tx.QueryContext(ctx, rawSQL.String(), whereParams...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		hit := &model.ReportSearchHit{Highlights: map[string]string{}}
+		headlines := make([]*string, len(searchFields))
+		dest := []interface{}{
+			&hit.ReportId,
+			&hit.MonthOf,
+			&hit.WorkerId,
+			&hit.WorkerName,
+			&hit.AreaOfAssignment,
+			&hit.NameOfChurch,
+			&hit.Status,
+			&hit.Rank,
+		}
+		for i := range headlines {
+			dest = append(dest, &headlines[i])
+		}
+
+		if err := rows.Scan(dest...); err != nil {
+			return nil, err
+		}
+		for i, headline := range headlines {
+			if headline != nil {
+				hit.Highlights[searchFields[i].key] = highlight(*headline)
+			}
+		}
+		result.Hits = append(result.Hits, hit)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// highlight escapes a ts_headline passage and marks the matching words
+func highlight(headline string) string {
+	escaped := html.EscapeString(headline)
+	escaped = strings.ReplaceAll(escaped, headlineStart, "<mark>")
+	return strings.ReplaceAll(escaped, headlineStop, "</mark>")
+}
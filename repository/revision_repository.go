@@ -0,0 +1,11 @@
+package repository
+
+import (
+	"context"
+	"reports/model"
+)
+
+type RevisionRepository interface {
+	FindByReport(ctx context.Context, reportId int) ([]*model.ReportRevision, error)
+	FindById(ctx context.Context, reportId, revisionId int) (*model.ReportRevision, error)
+}
@@ -0,0 +1,147 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"reports/model"
+)
+
+type RevisionRepositoryImpl struct {
+	Db *sql.DB
+}
+
+func NewRevisionRepository(Db *sql.DB) RevisionRepository {
+	return &RevisionRepositoryImpl{Db: Db}
+}
+
+// recordRevision inserts the revision of a report in the transaction that
+// changes the report. If it fails the transaction is rolled back, so the
+// change is not saved without its history. A nil revision records nothing.
+func recordRevision(ctx context.Context, tx *sql.Tx, revision *model.ReportRevision, reportId int) error {
+	if revision == nil {
+		return nil
+	}
+	revision.ReportId = reportId
+
+	if err := insertRevision(ctx, tx, revision); err != nil {
+		tx.Rollback()
+		return fmt.Errorf("failed to record revision: %w", err)
+	}
+	return nil
+}
+
+func insertRevision(ctx context.Context, tx *sql.Tx, revision *model.ReportRevision) error {
+	changesJSON, err := json.Marshal(revision.Changes)
+	if err != nil {
+		return err
+	}
+
+	snapshotJSON, err := json.Marshal(revision.Snapshot)
+	if err != nil {
+		return err
+	}
+
+	rawSQL := `
+		INSERT INTO report_revisions (report_id, action, actor_id, changes, snapshot, created_at)
+		VALUES ($1, $2, NULLIF($3, 0), $4, $5, $6)
+		RETURNING id
+	`
+
+	return tx.QueryRowContext(ctx, rawSQL,
+		revision.ReportId,
+		revision.Action,
+		revision.ActorId,
+		changesJSON,
+		snapshotJSON,
+		revision.CreatedAt,
+	).Scan(&revision.Id)
+}
+
+// FindByReport implements RevisionRepository. Revisions are listed oldest
+// first, without their snapshots.
+func (r *RevisionRepositoryImpl) FindByReport(ctx context.Context, reportId int) ([]*model.ReportRevision, error) {
+	rawSQL := `
+		SELECT` + revisionColumns + `, NULL
+		FROM report_revisions v
+		LEFT JOIN users u ON u.id = v.actor_id
+		WHERE v.report_id = $1
+		ORDER BY v.id
+	`
+
+	rows, err := r.Db.QueryContext(ctx, rawSQL, reportId)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	revisions := []*model.ReportRevision{}
+	for rows.Next() {
+		revision, err := scanRevision(rows)
+		if err != nil {
+			return nil, err
+		}
+		revisions = append(revisions, revision)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return revisions, nil
+}
+
+// FindById implements RevisionRepository
+func (r *RevisionRepositoryImpl) FindById(ctx context.Context, reportId, revisionId int) (*model.ReportRevision, error) {
+	rawSQL := `
+		SELECT` + revisionColumns + `, v.snapshot
+		FROM report_revisions v
+		LEFT JOIN users u ON u.id = v.actor_id
+		WHERE v.report_id = $1 AND v.id = $2
+	`
+
+	return scanRevision(r.Db.QueryRowContext(ctx, rawSQL, reportId, revisionId))
+}
+
+// revisionColumns is followed by the snapshot column, or NULL when listing
+const revisionColumns = `
+			v.id,
+			v.report_id,
+			v.action,
+			COALESCE(v.actor_id, 0),
+			COALESCE(u.name, ''),
+			v.changes,
+			v.created_at`
+
+func scanRevision(row rowScanner) (*model.ReportRevision, error) {
+	var revision model.ReportRevision
+	var changesJSON, snapshotJSON []byte
+
+	err := row.Scan(
+		&revision.Id,
+		&revision.ReportId,
+		&revision.Action,
+		&revision.ActorId,
+		&revision.ActorName,
+		&changesJSON,
+		&revision.CreatedAt,
+		&snapshotJSON,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if changesJSON != nil {
+		if err := json.Unmarshal(changesJSON, &revision.Changes); err != nil {
+			return nil, err
+		}
+	}
+	if snapshotJSON != nil {
+		if err := json.Unmarshal(snapshotJSON, &revision.Snapshot); err != nil {
+			return nil, err
+		}
+	}
+
+	return &revision, nil
+}
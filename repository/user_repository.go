@@ -0,0 +1,12 @@
+package repository
+
+import (
+	"context"
+	"reports/model"
+)
+
+type UserRepository interface {
+	Save(ctx context.Context, user *model.User) error
+	FindById(ctx context.Context, userId int) (*model.User, error)
+	FindByEmail(ctx context.Context, email string) (*model.User, error)
+}
@@ -0,0 +1,132 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"reports/helper"
+	"reports/model"
+	"strings"
+)
+
+type UserRepositoryImpl struct {
+	Db *sql.DB
+}
+
+func NewUserRepository(Db *sql.DB) UserRepository {
+	return &UserRepositoryImpl{Db: Db}
+}
+
+// Save implements UserRepository
+func (r *UserRepositoryImpl) Save(ctx context.Context, user *model.User) error {
+	tx, err := r.Db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer helper.CommitOrRollback(tx)
+
+	areaIdsJSON, err := json.Marshal(user.AreaIds)
+	if err != nil {
+		return err
+	}
+	if user.AreaIds == nil {
+		areaIdsJSON = []byte("[]")
+	}
+
+	rawSQL := `
+		INSERT INTO users (
+			name,
+			email,
+			password,
+			role,
+			worker_id,
+			area_ids,
+			created_at,
+			updated_at
+		) VALUES ($1, $2, $3, $4, NULLIF($5, 0), $6, $7, $8)
+		RETURNING id
+	`
+
+	err = tx.QueryRowContext(ctx, rawSQL,
+		user.Name,
+		strings.ToLower(user.Email),
+		user.Password,
+		user.Role,
+		user.WorkerId,
+		areaIdsJSON,
+		user.CreatedAt,
+		user.UpdatedAt,
+	).Scan(&user.Id)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// FindById implements UserRepository
+func (r *UserRepositoryImpl) FindById(ctx context.Context, userId int) (*model.User, error) {
+	rawSQL := `
+		SELECT
+			id,
+			name,
+			email,
+			password,
+			role,
+			COALESCE(worker_id, 0),
+			area_ids,
+			created_at,
+			updated_at
+		FROM users
+		WHERE id = $1
+	`
+
+	return r.findOne(ctx, rawSQL, userId)
+}
+
+// FindByEmail implements UserRepository
+func (r *UserRepositoryImpl) FindByEmail(ctx context.Context, email string) (*model.User, error) {
+	rawSQL := `
+		SELECT
+			id,
+			name,
+			email,
+			password,
+			role,
+			COALESCE(worker_id, 0),
+			area_ids,
+			created_at,
+			updated_at
+		FROM users
+		WHERE email = $1
+	`
+
+	return r.findOne(ctx, rawSQL, strings.ToLower(email))
+}
+
+func (r *UserRepositoryImpl) findOne(ctx context.Context, rawSQL string, args ...interface{}) (*model.User, error) {
+	var user model.User
+	var areaIdsJSON []byte
+	err := r.Db.QueryRowContext(ctx, rawSQL, args...).Scan(
+		&user.Id,
+		&user.Name,
+		&user.Email,
+		&user.Password,
+		&user.Role,
+		&user.WorkerId,
+		&areaIdsJSON,
+		&user.CreatedAt,
+		&user.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if areaIdsJSON != nil {
+		if err := json.Unmarshal(areaIdsJSON, &user.AreaIds); err != nil {
+			return nil, err
+		}
+	}
+
+	return &user, nil
+}
@@ -0,0 +1,16 @@
+package repository
+
+import (
+	"context"
+	"reports/model"
+)
+
+type WorkerRepository interface {
+	Save(ctx context.Context, worker *model.Worker) error
+	Update(ctx context.Context, worker *model.Worker) error
+	Delete(ctx context.Context, workerId int) error
+	FindById(ctx context.Context, workerId int) (*model.Worker, error)
+	FindByName(ctx context.Context, name string) (*model.Worker, error)
+	FindAll(ctx context.Context, query *model.SearchWorkerQuery) (*model.SearchWorkerResult, error)
+	Merge(ctx context.Context, targetId int, sourceIds []int) error
+}
@@ -0,0 +1,296 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"reports/helper"
+	"reports/model"
+	"strconv"
+	"strings"
+
+	"github.com/lib/pq"
+)
+
+type WorkerRepositoryImpl struct {
+	Db *sql.DB
+}
+
+func NewWorkerRepository(Db *sql.DB) WorkerRepository {
+	return &WorkerRepositoryImpl{Db: Db}
+}
+
+// Save implements WorkerRepository
+func (r *WorkerRepositoryImpl) Save(ctx context.Context, worker *model.Worker) error {
+	tx, err := r.Db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer helper.CommitOrRollback(tx)
+
+	rawSQL := `
+		INSERT INTO workers (
+			name,
+			created_at,
+			updated_at
+		) VALUES ($1, $2, $3)
+		RETURNING id
+	`
+
+	err = tx.QueryRowContext(ctx, rawSQL, worker.Name, worker.CreatedAt, worker.UpdatedAt).Scan(&worker.Id)
+	if err != nil {
+		if isUniqueViolation(err, "workers_name_key") {
+			return ErrWorkerNameTaken
+		}
+		return err
+	}
+
+	return nil
+}
+
+// Update implements WorkerRepository
+func (r *WorkerRepositoryImpl) Update(ctx context.Context, worker *model.Worker) error {
+	tx, err := r.Db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer helper.CommitOrRollback(tx)
+
+	rawSQL := `
+		UPDATE workers SET
+			name = $1,
+			updated_at = $2
+		WHERE id = $3
+	`
+
+	_, err = tx.ExecContext(ctx, rawSQL, worker.Name, worker.UpdatedAt, worker.Id)
+	if err != nil {
+		if isUniqueViolation(err, "workers_name_key") {
+			return ErrWorkerNameTaken
+		}
+		return err
+	}
+
+	return nil
+}
+
+// Delete implements WorkerRepository
+func (r *WorkerRepositoryImpl) Delete(ctx context.Context, workerId int) error {
+	tx, err := r.Db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer helper.CommitOrRollback(tx)
+
+	rawSQL := `
+		DELETE FROM workers
+		WHERE id = $1
+	`
+
+	_, err = tx.ExecContext(ctx, rawSQL, workerId)
+	if err != nil {
+		if isForeignKeyViolation(err) {
+			return ErrWorkerInUse
+		}
+		return err
+	}
+
+	return nil
+}
+
+// FindById implements WorkerRepository
+func (r *WorkerRepositoryImpl) FindById(ctx context.Context, workerId int) (*model.Worker, error) {
+	rawSQL := `
+		SELECT
+			w.id,
+			w.name,
+			(SELECT COUNT(*) FROM reports t WHERE t.worker_id = w.id AND t.deleted_at IS NULL),
+			w.created_at,
+			w.updated_at
+		FROM workers w
+		WHERE w.id = $1
+	`
+
+	return scanWorker(r.Db.QueryRowContext(ctx, rawSQL, workerId))
+}
+
+// FindByName implements WorkerRepository
+func (r *WorkerRepositoryImpl) FindByName(ctx context.Context, name string) (*model.Worker, error) {
+	rawSQL := `
+		SELECT
+			w.id,
+			w.name,
+			(SELECT COUNT(*) FROM reports t WHERE t.worker_id = w.id AND t.deleted_at IS NULL),
+			w.created_at,
+			w.updated_at
+		FROM workers w
+		WHERE LOWER(w.name) = LOWER(TRIM($1))
+	`
+
+	return scanWorker(r.Db.QueryRowContext(ctx, rawSQL, name))
+}
+
+// FindAll implements WorkerRepository
+func (r *WorkerRepositoryImpl) FindAll(ctx context.Context, query *model.SearchWorkerQuery) (*model.SearchWorkerResult, error) {
+	var rawSQL strings.Builder
+	rawSQL.WriteString(`
+		SELECT
+			w.id,
+			w.name,
+			(SELECT COUNT(*) FROM reports t WHERE t.worker_id = w.id AND t.deleted_at IS NULL),
+			w.created_at,
+			w.updated_at,
+			COUNT(*) OVER()
+		FROM workers w
+	`)
+
+	var whereParams []interface{}
+	index := 1
+
+	if query.Name != "" {
+		rawSQL.WriteString(" WHERE LOWER(w.name) LIKE $" + strconv.Itoa(index))
+		whereParams = append(whereParams, "%"+strings.ToLower(query.Name)+"%")
+		index++
+	}
+
+	rawSQL.WriteString(" ORDER BY w.name, w.id")
+
+	// Pagination, a PerPage of zero returns every worker
+	if query.PerPage > 0 {
+		rawSQL.WriteString(" LIMIT $" + strconv.Itoa(index))
+		rawSQL.WriteString(" OFFSET $" + strconv.Itoa(index+1))
+		whereParams = append(whereParams, query.PerPage, (query.Page-1)*query.PerPage)
+	}
+
+	rows, err := r.Db.QueryContext(ctx, rawSQL.String(), whereParams...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	result := &model.SearchWorkerResult{
+		Workers: []*model.Worker{},
+		Page:    query.Page,
+		PerPage: query.PerPage,
+	}
+
+	for rows.Next() {
+		var worker model.Worker
+		if err := rows.Scan(
+			&worker.Id,
+			&worker.Name,
+			&worker.ReportCount,
+			&worker.CreatedAt,
+			&worker.UpdatedAt,
+			&result.TotalCount,
+		); err != nil {
+			return nil, err
+		}
+		result.Workers = append(result.Workers, &worker)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// Merge implements WorkerRepository. Reports and user accounts of the source
+// workers move to the target and the source workers are deleted. Nothing is
+// changed if the move would leave the target with two reports for a month.
+func (r *WorkerRepositoryImpl) Merge(ctx context.Context, targetId int, sourceIds []int) error {
+	tx, err := r.Db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer helper.CommitOrRollback(tx)
+
+	// A worker listed twice is merged once; the target cannot be a source
+	seen := map[int]bool{}
+	var uniqueIds []int
+	for _, id := range sourceIds {
+		if id == targetId {
+			return ErrWorkerMergedIntoItself
+		}
+		if !seen[id] {
+			seen[id] = true
+			uniqueIds = append(uniqueIds, id)
+		}
+	}
+	sourceIds = uniqueIds
+
+	allIds := append([]int{targetId}, sourceIds...)
+
+	// Locking the workers holds back reports written for them meanwhile:
+	// inserting a report key-share locks its worker
+	if _, err := tx.ExecContext(ctx, `SELECT id FROM workers WHERE id = ANY($1) FOR UPDATE`, pq.Array(allIds)); err != nil {
+		return err
+	}
+
+	conflictSQL := `
+		SELECT month_of
+		FROM reports
+		WHERE worker_id = ANY($1)
+		AND deleted_at IS NULL
+		GROUP BY month_of
+		HAVING COUNT(*) > 1
+		ORDER BY month_of
+	`
+
+	rows, err := tx.QueryContext(ctx, conflictSQL, pq.Array(allIds))
+	if err != nil {
+		return err
+	}
+
+	var conflicts []string
+	for rows.Next() {
+		var month model.Period
+		if err := rows.Scan(&month); err != nil {
+			rows.Close()
+			return err
+		}
+		conflicts = append(conflicts, month.Label())
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	if len(conflicts) > 0 {
+		return fmt.Errorf("%w: %s", ErrReportTaken, strings.Join(conflicts, ", "))
+	}
+
+	statements := []string{
+		`UPDATE reports SET worker_id = $1 WHERE worker_id = ANY($2)`,
+		`UPDATE users SET worker_id = $1 WHERE worker_id = ANY($2)`,
+		`DELETE FROM workers WHERE id = ANY($2) AND id <> $1`,
+	}
+
+	for _, statement := range statements {
+		if _, err := tx.ExecContext(ctx, statement, targetId, pq.Array(sourceIds)); err != nil {
+			if isUniqueViolation(err, "reports_worker_month_key") {
+				return ErrReportTaken
+			}
+			return err
+		}
+	}
+
+	return nil
+}
+
+func scanWorker(row rowScanner) (*model.Worker, error) {
+	var worker model.Worker
+	err := row.Scan(
+		&worker.Id,
+		&worker.Name,
+		&worker.ReportCount,
+		&worker.CreatedAt,
+		&worker.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &worker, nil
+}
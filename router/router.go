@@ -2,12 +2,23 @@ package router
 
 import (
 	"net/http"
+	"reports/config"
 	"reports/controller"
+	"reports/middleware"
+	"reports/model"
+	"reports/repository"
 
 	"github.com/gin-gonic/gin"
 )
 
-func NewRouter(reportController *controller.ReportController) *gin.Engine {
+func NewRouter(
+	config *config.Config,
+	userRepository repository.UserRepository,
+	authController *controller.AuthController,
+	workerController *controller.WorkerController,
+	organizationController *controller.OrganizationController,
+	reportController *controller.ReportController,
+) *gin.Engine {
 	service := gin.Default()
 
 	service.GET("/", func(ctx *gin.Context) {
@@ -17,13 +28,77 @@ func NewRouter(reportController *controller.ReportController) *gin.Engine {
 	// Api Group
 	router := service.Group("/api")
 
-	router.GET("", reportController.FindAll)
-	router.POST("", reportController.Create)
-	router.GET("/:reportId", reportController.FindById)
-	router.PUT("/:reportId", reportController.Update)
-	router.DELETE("/:reportId", reportController.Delete)
+	// Auth
+	router.POST("/auth/login", authController.Login)
+	router.POST("/auth/logout", authController.Logout)
 
-	router.GET("/:reportId/export", reportController.ExportReport)
+	// Everything below requires a valid token
+	protected := router.Group("", middleware.DeserializeUser(userRepository, config))
+
+	protected.GET("/auth/me", authController.Me)
+	protected.POST("/auth/register", middleware.RequireRole(model.RoleAdmin), authController.Register)
+
+	// Workers
+	workers := protected.Group("/workers")
+	workers.GET("", middleware.RequireRole(model.RoleAdmin, model.RoleSupervisor), workerController.FindAll)
+	workers.GET("/duplicates", middleware.RequireRole(model.RoleAdmin), workerController.FindDuplicates)
+	workers.GET("/:workerId", middleware.RequireRole(model.RoleAdmin, model.RoleSupervisor), workerController.FindById)
+	workers.POST("", middleware.RequireRole(model.RoleAdmin), workerController.Create)
+	workers.PUT("/:workerId", middleware.RequireRole(model.RoleAdmin), workerController.Update)
+	workers.DELETE("/:workerId", middleware.RequireRole(model.RoleAdmin), workerController.Delete)
+	workers.POST("/:workerId/merge", middleware.RequireRole(model.RoleAdmin), workerController.Merge)
+	workers.GET("/:workerId/annual", reportController.AnnualReport)
+	workers.GET("/:workerId/annual/export", reportController.ExportAnnualReport)
+
+	// Church hierarchy: /regions, /districts, /areas and /churches
+	hierarchy := map[model.OrgLevel]string{
+		model.LevelRegion:   "/regions",
+		model.LevelDistrict: "/districts",
+		model.LevelArea:     "/areas",
+		model.LevelChurch:   "/churches",
+	}
+	for level, path := range hierarchy {
+		units := protected.Group(path)
+		units.GET("", organizationController.FindAll(level))
+		units.GET("/:id", organizationController.FindById(level))
+		units.POST("", middleware.RequireRole(model.RoleAdmin), organizationController.Create(level))
+		units.PUT("/:id", middleware.RequireRole(model.RoleAdmin), organizationController.Update(level))
+		units.DELETE("/:id", middleware.RequireRole(model.RoleAdmin), organizationController.Delete(level))
+	}
+
+	// Statistics
+	protected.GET("/stats/rollup", organizationController.Rollup)
+	protected.GET("/stats/trends", reportController.Trends)
+	protected.GET("/stats/area-summary", middleware.RequireRole(model.RoleAdmin, model.RoleSupervisor), reportController.AreaSummary)
+
+	// Reports
+	protected.GET("", reportController.FindAll)
+	protected.GET("/export", reportController.ExportReports)
+	protected.GET("/search", reportController.Search)
+	protected.GET("/people", reportController.FindPeople)
+	protected.POST("", reportController.Create)
+	protected.POST("/import", reportController.ImportReports)
+	protected.POST("/import/sheet", reportController.ImportSheet)
+	protected.GET("/:reportId", reportController.FindById)
+	protected.PUT("/:reportId", reportController.Update)
+	protected.PATCH("/:reportId", reportController.Patch)
+	protected.DELETE("/:reportId", reportController.Delete)
+
+	// Deleted reports stay in the trash until restored or purged
+	protected.GET("/trash", reportController.Trash)
+	protected.DELETE("/trash", middleware.RequireRole(model.RoleAdmin), reportController.Purge)
+	protected.POST("/:reportId/restore", reportController.Restore)
+
+	protected.GET("/:reportId/export", reportController.ExportReport)
+
+	// Review workflow: draft -> submitted -> approved or returned
+	protected.POST("/:reportId/submit", reportController.Submit)
+	protected.POST("/:reportId/approve", middleware.RequireRole(model.RoleAdmin, model.RoleSupervisor), reportController.Approve)
+	protected.POST("/:reportId/return", middleware.RequireRole(model.RoleAdmin, model.RoleSupervisor), reportController.Return)
+
+	// Revision history
+	protected.GET("/:reportId/history", reportController.History)
+	protected.GET("/:reportId/history/:revisionId", reportController.Revision)
 
 	return service
 }
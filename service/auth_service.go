@@ -0,0 +1,13 @@
+package service
+
+import (
+	"context"
+	"reports/data/request"
+	"reports/data/response"
+)
+
+type AuthService interface {
+	Login(ctx context.Context, request *request.LoginRequest) (*response.LoginResponse, error)
+	Register(ctx context.Context, request *request.UserCreateRequest) (*response.UserResponse, error)
+	EnsureUser(ctx context.Context, request *request.UserCreateRequest) error
+}
@@ -0,0 +1,110 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"reports/config"
+	"reports/data/request"
+	"reports/data/response"
+	"reports/model"
+	"reports/repository"
+	"reports/utils"
+	"time"
+)
+
+type AuthServiceImpl struct {
+	userRepository repository.UserRepository
+	config         *config.Config
+}
+
+func NewAuthServiceImpl(userRepository repository.UserRepository, config *config.Config) AuthService {
+	return &AuthServiceImpl{userRepository: userRepository, config: config}
+}
+
+// dummyPasswordHash is compared against when the email is unknown, so that
+// a login takes as long whether or not the account exists
+const dummyPasswordHash = "$2a$10$6ZWKJVWpmJoac5TXKBP68.FQ1spOIj.01OADC0gJQr4MJNGyMvxPi"
+
+func (a *AuthServiceImpl) Login(ctx context.Context, request *request.LoginRequest) (*response.LoginResponse, error) {
+	user, err := a.userRepository.FindByEmail(ctx, request.Email)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			utils.VerifyPassword(dummyPasswordHash, request.Password)
+			return nil, ErrInvalidCredentials
+		}
+		return nil, err
+	}
+
+	if err := utils.VerifyPassword(user.Password, request.Password); err != nil {
+		return nil, ErrInvalidCredentials
+	}
+
+	token, expiresAt, err := utils.GenerateToken(a.config.TokenExpiresIn, user.Id, a.config.TokenSecret)
+	if err != nil {
+		return nil, err
+	}
+
+	return &response.LoginResponse{
+		Token:     token,
+		ExpiresAt: expiresAt,
+		User:      toUserResponse(user),
+	}, nil
+}
+
+func (a *AuthServiceImpl) Register(ctx context.Context, request *request.UserCreateRequest) (*response.UserResponse, error) {
+	_, err := a.userRepository.FindByEmail(ctx, request.Email)
+	if err == nil {
+		return nil, ErrEmailTaken
+	}
+	if !errors.Is(err, sql.ErrNoRows) {
+		return nil, err
+	}
+
+	hashedPassword, err := utils.HashPassword(request.Password)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	user := model.User{
+		Name:      request.Name,
+		Email:     request.Email,
+		Password:  hashedPassword,
+		Role:      request.Role,
+		WorkerId:  request.WorkerId,
+		AreaIds:   request.AreaIds,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+
+	if err := a.userRepository.Save(ctx, &user); err != nil {
+		return nil, fmt.Errorf("failed to save user: %w", err)
+	}
+
+	return toUserResponse(&user), nil
+}
+
+// EnsureUser creates the given user unless the email is already registered.
+// It is used on startup to seed the first account from the environment.
+func (a *AuthServiceImpl) EnsureUser(ctx context.Context, request *request.UserCreateRequest) error {
+	_, err := a.Register(ctx, request)
+	if err != nil && !errors.Is(err, ErrEmailTaken) {
+		return err
+	}
+	return nil
+}
+
+func toUserResponse(user *model.User) *response.UserResponse {
+	return &response.UserResponse{
+		Id:        user.Id,
+		Name:      user.Name,
+		Email:     user.Email,
+		Role:      user.Role,
+		WorkerId:  user.WorkerId,
+		AreaIds:   user.AreaIds,
+		CreatedAt: user.CreatedAt,
+		UpdatedAt: user.UpdatedAt,
+	}
+}
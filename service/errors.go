@@ -0,0 +1,55 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"reports/model"
+)
+
+var (
+	ErrInvalidCredentials = errors.New("invalid email or password")
+	ErrEmailTaken         = errors.New("email is already registered")
+	ErrReportNotFound     = errors.New("report not found")
+	ErrForbidden          = errors.New("you are not allowed to perform this action")
+)
+
+var (
+	ErrInvalidTransition = errors.New("invalid status change")
+	ErrReportLocked      = errors.New("the report can no longer be edited")
+	ErrReportIncomplete  = errors.New("the report is not complete")
+	ErrRevisionNotFound  = errors.New("revision not found")
+	ErrInvalidPatch      = errors.New("invalid patch")
+	ErrReportModified    = errors.New("the report has been changed by someone else; reload it and try again")
+	ErrVersionRequired   = errors.New("the report already exists; send the version from the preview in If-Match")
+	ErrPersonNameMissing = errors.New("name must not be empty")
+	ErrSearchTermMissing = errors.New("q must not be empty")
+)
+
+// ReportAlreadySubmittedError is returned when a worker already has a report
+// for the month being created or updated
+type ReportAlreadySubmittedError struct {
+	ReportId   int
+	MonthOf    model.Period
+	WorkerName string
+}
+
+func (e *ReportAlreadySubmittedError) Error() string {
+	return fmt.Sprintf("a report for %s for %s has already been submitted", e.WorkerName, e.MonthOf.Label())
+}
+
+var (
+	ErrWorkerNotFound      = errors.New("worker not found")
+	ErrWorkerNameTaken     = errors.New("a worker with this name already exists")
+	ErrWorkerInUse         = errors.New("worker still has reports or a user account")
+	ErrWorkerMergeConflict = errors.New("merging would give the worker two reports for the same month")
+	ErrWorkerMergeSelf     = errors.New("a worker cannot be merged into itself")
+)
+
+var (
+	ErrOrgUnitNotFound  = errors.New("unit not found")
+	ErrOrgUnitNameTaken = errors.New("a unit with this name already exists under the same parent")
+	ErrOrgUnitInUse     = errors.New("unit still has child units or reports")
+	ErrParentNotFound   = errors.New("parent unit not found")
+	ErrChurchNotFound   = errors.New("church not found")
+	ErrAreaNotFound     = errors.New("area not found")
+)
@@ -0,0 +1,16 @@
+package service
+
+import (
+	"context"
+	"reports/data/request"
+	"reports/model"
+)
+
+type OrganizationService interface {
+	Create(ctx context.Context, request *request.OrgUnitRequest) (*model.OrgUnit, error)
+	Update(ctx context.Context, request *request.OrgUnitRequest) (*model.OrgUnit, error)
+	Delete(ctx context.Context, level model.OrgLevel, id int) error
+	FindById(ctx context.Context, level model.OrgLevel, id int) (*model.OrgUnit, error)
+	FindAll(ctx context.Context, query *model.SearchOrgUnitQuery) ([]*model.OrgUnit, error)
+	Rollup(ctx context.Context, query *model.RollupQuery) (*model.RollupResult, error)
+}
@@ -0,0 +1,143 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"reports/data/request"
+	"reports/model"
+	"reports/repository"
+	"time"
+)
+
+type OrganizationServiceImpl struct {
+	organizationRepository repository.OrganizationRepository
+}
+
+func NewOrganizationServiceImpl(organizationRepository repository.OrganizationRepository) OrganizationService {
+	return &OrganizationServiceImpl{organizationRepository: organizationRepository}
+}
+
+func (o *OrganizationServiceImpl) Create(ctx context.Context, request *request.OrgUnitRequest) (*model.OrgUnit, error) {
+	if err := o.checkParent(ctx, request); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	unit := model.OrgUnit{
+		Level:     request.Level,
+		ParentId:  request.ParentId,
+		Name:      request.Name,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+
+	if err := o.organizationRepository.Save(ctx, &unit); err != nil {
+		return nil, mapOrgUnitError(err)
+	}
+
+	return o.FindById(ctx, unit.Level, unit.Id)
+}
+
+func (o *OrganizationServiceImpl) Update(ctx context.Context, request *request.OrgUnitRequest) (*model.OrgUnit, error) {
+	unit, err := o.FindById(ctx, request.Level, request.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := o.checkParent(ctx, request); err != nil {
+		return nil, err
+	}
+
+	unit.ParentId = request.ParentId
+	unit.Name = request.Name
+	unit.UpdatedAt = time.Now()
+
+	if err := o.organizationRepository.Update(ctx, unit); err != nil {
+		return nil, mapOrgUnitError(err)
+	}
+
+	return o.FindById(ctx, unit.Level, unit.Id)
+}
+
+func (o *OrganizationServiceImpl) Delete(ctx context.Context, level model.OrgLevel, id int) error {
+	unit, err := o.FindById(ctx, level, id)
+	if err != nil {
+		return err
+	}
+
+	if err := o.organizationRepository.Delete(ctx, unit.Level, unit.Id); err != nil {
+		return mapOrgUnitError(err)
+	}
+
+	return nil
+}
+
+func (o *OrganizationServiceImpl) FindById(ctx context.Context, level model.OrgLevel, id int) (*model.OrgUnit, error) {
+	unit, err := o.organizationRepository.FindById(ctx, level, id)
+	if err != nil {
+		return nil, mapOrgUnitError(err)
+	}
+
+	return unit, nil
+}
+
+func (o *OrganizationServiceImpl) FindAll(ctx context.Context, query *model.SearchOrgUnitQuery) ([]*model.OrgUnit, error) {
+	return o.organizationRepository.FindAll(ctx, query)
+}
+
+// Rollup aggregates a metric over the reports the caller may see, grouped
+// by church, area, district or region
+func (o *OrganizationServiceImpl) Rollup(ctx context.Context, query *model.RollupQuery) (*model.RollupResult, error) {
+	user, err := currentUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if query.Filter == nil {
+		query.Filter = &model.SearchReportQuery{}
+	}
+	query.Filter.Scope = user.ReportScope()
+
+	rows, err := o.organizationRepository.Rollup(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+
+	return &model.RollupResult{
+		Metric: query.Metric,
+		Level:  query.Level,
+		Rows:   rows,
+	}, nil
+}
+
+// checkParent makes sure the requested parent exists one level up
+func (o *OrganizationServiceImpl) checkParent(ctx context.Context, request *request.OrgUnitRequest) error {
+	parentLevel := request.Level.Parent()
+	if parentLevel == "" {
+		request.ParentId = 0
+		return nil
+	}
+
+	_, err := o.organizationRepository.FindById(ctx, parentLevel, request.ParentId)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return ErrParentNotFound
+		}
+		return err
+	}
+
+	return nil
+}
+
+func mapOrgUnitError(err error) error {
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+		return ErrOrgUnitNotFound
+	case errors.Is(err, repository.ErrOrgUnitNameTaken):
+		return ErrOrgUnitNameTaken
+	case errors.Is(err, repository.ErrOrgUnitInUse):
+		return ErrOrgUnitInUse
+	}
+	return err
+}
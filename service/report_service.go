@@ -10,7 +10,24 @@ import (
 type ReportService interface {
 	Create(ctx context.Context, request *request.ReportCreateRequest) error
 	Update(ctx context.Context, request *request.ReportUpdateRequest) error
+	Patch(ctx context.Context, reportId, version int, patch map[string]interface{}) (*response.ReportResponse, error)
 	Delete(ctx context.Context, reportId int) error
+	Trash(ctx context.Context, query *model.SearchReportQuery) (*model.SearchReportResult, error)
+	Restore(ctx context.Context, reportId int) (*response.ReportResponse, error)
+	Purge(ctx context.Context) (*response.PurgeResponse, error)
+	Submit(ctx context.Context, reportId int) (*response.ReportResponse, error)
+	Approve(ctx context.Context, reportId int, request *request.ReportReviewRequest) (*response.ReportResponse, error)
+	Return(ctx context.Context, reportId int, request *request.ReportReviewRequest) (*response.ReportResponse, error)
+	History(ctx context.Context, reportId int) ([]*model.ReportRevision, error)
+	Revision(ctx context.Context, reportId, revisionId int) (*response.ReportRevisionResponse, error)
 	FindById(ctx context.Context, reportId int) (*response.ReportResponse, error)
 	FindAll(ctx context.Context, query *model.SearchReportQuery) (*model.SearchReportResult, error)
+	Search(ctx context.Context, query *model.SearchReportQuery) (*model.ReportSearchResult, error)
+	FindAllForExport(ctx context.Context, query *model.SearchReportQuery) ([]*response.ReportResponse, error)
+	FindPeople(ctx context.Context, name string, query *model.SearchReportQuery) ([]*model.PersonOccurrence, error)
+	AreaSummary(ctx context.Context, areaId int, monthOf model.Period) (*model.AreaSummary, error)
+	Trends(ctx context.Context, query *model.TrendQuery) (*model.TrendResult, error)
+	AnnualReport(ctx context.Context, workerId, year int, through model.Period) (*model.AnnualReport, error)
+	Import(ctx context.Context, rows []*request.ReportImportRow) (*response.ImportResponse, error)
+	ImportSheet(ctx context.Context, sheet *request.ReportSheetImport, apply bool) (*response.ReportSheetImportResponse, error)
 }
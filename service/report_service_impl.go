@@ -2,35 +2,72 @@ package service
 
 import (
 	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"reports/config"
 	"reports/data/request"
 	"reports/data/response"
 	"reports/model"
 	"reports/repository"
+	"reports/utils"
+	"sort"
+	"strings"
 	"time"
 
 	"github.com/xuri/excelize/v2"
 )
 
 type ReportServiceImpl struct {
-	reportRepository repository.ReportRepository
-	paginationConfig config.PaginationConfig
+	reportRepository       repository.ReportRepository
+	workerRepository       repository.WorkerRepository
+	organizationRepository repository.OrganizationRepository
+	revisionRepository     repository.RevisionRepository
+	paginationConfig       config.PaginationConfig
+	config                 *config.Config
 }
 
-func NewReportServiceImpl(reportRepository repository.ReportRepository) ReportService {
-	return &ReportServiceImpl{reportRepository: reportRepository}
+func NewReportServiceImpl(
+	reportRepository repository.ReportRepository,
+	workerRepository repository.WorkerRepository,
+	organizationRepository repository.OrganizationRepository,
+	revisionRepository repository.RevisionRepository,
+	config *config.Config,
+) ReportService {
+	return &ReportServiceImpl{
+		reportRepository:       reportRepository,
+		workerRepository:       workerRepository,
+		organizationRepository: organizationRepository,
+		revisionRepository:     revisionRepository,
+		paginationConfig:       config.Pagination,
+		config:                 config,
+	}
 }
 
 func (r *ReportServiceImpl) Create(ctx context.Context, request *request.ReportCreateRequest) error {
-	// result, err := r.reportRepository.ReportTaken(ctx, 0, request.MonthOf, request.WorkerName)
-	// if err != nil {
-	// 	return err
-	// }
+	user, err := currentUser(ctx)
+	if err != nil {
+		return err
+	}
+
+	church, err := r.findChurch(ctx, request.ChurchId)
+	if err != nil {
+		return err
+	}
 
-	// if result != nil {
-	// 	return errors.New("report already taken")
-	// }
+	if !user.CanAccessReport(request.WorkerId, church.ParentId) {
+		return ErrForbidden
+	}
+
+	worker, err := r.findWorker(ctx, request.WorkerId)
+	if err != nil {
+		return err
+	}
+
+	if err := r.checkReportTaken(ctx, 0, request.MonthOf, request.WorkerId); err != nil {
+		return err
+	}
 
 	loc, err := time.LoadLocation("Asia/Manila")
 	if err != nil {
@@ -41,9 +78,12 @@ func (r *ReportServiceImpl) Create(ctx context.Context, request *request.ReportC
 
 	report := model.Report{
 		MonthOf:                         request.MonthOf,
-		WorkerName:                      request.WorkerName,
-		AreaOfAssignment:                request.AreaOfAssignment,
-		NameOfChurch:                    request.NameOfChurch,
+		WorkerId:                        worker.Id,
+		WorkerName:                      worker.Name,
+		ChurchId:                        church.Id,
+		AreaId:                          church.ParentId,
+		AreaOfAssignment:                church.ParentName,
+		NameOfChurch:                    church.Name,
 		WorshipService:                  request.WorshipService,
 		SundaySchool:                    request.SundaySchool,
 		PrayerMeetings:                  request.PrayerMeetings,
@@ -59,54 +99,176 @@ func (r *ReportServiceImpl) Create(ctx context.Context, request *request.ReportC
 		Others:                          request.Others,
 		FamilyDays:                      request.FamilyDays,
 		TithesAndOfferings:              request.TithesAndOfferings,
+		Currency:                        currencyOrDefault(request.Currency, model.DefaultCurrency),
 		HomeVisited:                     request.HomeVisited,
 		BibleStudyOrGroupLed:            request.BibleStudyOrGroupLed,
 		SermonOrMessagePreached:         request.SermonOrMessagePreached,
 		PersonNewlyContacted:            request.PersonNewlyContacted,
 		PersonFollowedUp:                request.PersonFollowedUp,
 		PersonLedToChrist:               request.PersonLedToChrist,
-		Names:                           request.Names,
+		People:                          request.People,
 		NarrativeReport:                 request.NarrativeReport,
 		ChallengesAndProblemEncountered: request.ChallengesAndProblemEncountered,
 		PrayerRequest:                   request.PrayerRequest,
+		Status:                          model.StatusDraft,
 		CreatedAt:                       now,
 		UpdatedAt:                       now,
 	}
 
+	revision, err := r.revision(ctx, model.ActionCreate, nil, &report)
+	if err != nil {
+		return err
+	}
+
 	// Save the report using the repository
-	err = r.reportRepository.Save(ctx, &report)
+	err = r.reportRepository.Save(ctx, &report, revision)
 	if err != nil {
+		if errors.Is(err, repository.ErrReportTaken) {
+			if takenErr := r.checkReportTaken(ctx, 0, report.MonthOf, report.WorkerId); takenErr != nil {
+				return takenErr
+			}
+		}
 		return fmt.Errorf("failed to save report: %w", err)
 	}
 
 	return nil
 }
 
+// Delete moves a report to the trash
 func (r *ReportServiceImpl) Delete(ctx context.Context, reportId int) error {
+	user, err := currentUser(ctx)
+	if err != nil {
+		return err
+	}
+
 	// Retrieve the report by its ID
-	report, err := r.reportRepository.FindById(ctx, reportId)
+	report, err := r.findReport(ctx, reportId)
 	if err != nil {
 		return err // Return error if FindById fails
 	}
 
-	// Delete the report using its ID
-	err = r.reportRepository.Delete(ctx, report.Id)
+	// Submitted and approved reports are frozen except for admins
+	if !report.Status.Editable() && user.Role != model.RoleAdmin {
+		return fmt.Errorf("%w: the report is %s", ErrReportLocked, report.Status)
+	}
+
+	before := *report
+
+	now := time.Now()
+	report.DeletedAt = &now
+	report.DeletedBy = user.Id
+
+	revision, err := r.revision(ctx, model.ActionDelete, &before, report)
 	if err != nil {
-		return err // Return error if Delete fails
+		return err
 	}
 
-	return nil
+	return r.reportRepository.Delete(ctx, report, revision)
 }
 
-func (r *ReportServiceImpl) FindAll(ctx context.Context, query *model.SearchReportQuery) (*model.SearchReportResult, error) {
-	// Initialize pagination parameters if they are not provided or invalid
-	if query.Page <= 0 {
-		query.Page = r.paginationConfig.Page
+// Trash lists the deleted reports the caller is allowed to see
+func (r *ReportServiceImpl) Trash(ctx context.Context, query *model.SearchReportQuery) (*model.SearchReportResult, error) {
+	query.Deleted = true
+	return r.FindAll(ctx, query)
+}
+
+// Restore takes a report out of the trash, unless another report has been
+// created for the same worker and month in the meantime
+func (r *ReportServiceImpl) Restore(ctx context.Context, reportId int) (*response.ReportResponse, error) {
+	user, err := currentUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	report, err := r.reportRepository.FindDeletedById(ctx, reportId)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrReportNotFound
+		}
+		return nil, err
+	}
+
+	if !user.CanAccessReport(report.WorkerId, report.AreaId) {
+		return nil, ErrReportNotFound
+	}
+
+	// Only an admin can bring back a report that was submitted or approved
+	if !report.Status.Editable() && user.Role != model.RoleAdmin {
+		return nil, fmt.Errorf("%w: the report is %s", ErrReportLocked, report.Status)
+	}
+
+	if err := r.checkReportTaken(ctx, report.Id, report.MonthOf, report.WorkerId); err != nil {
+		return nil, err
+	}
+
+	before := *report
+
+	report.DeletedAt = nil
+	report.DeletedBy = 0
+	report.UpdatedAt = time.Now()
+
+	revision, err := r.revision(ctx, model.ActionRestore, &before, report)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := r.reportRepository.Restore(ctx, report, revision); err != nil {
+		if errors.Is(err, repository.ErrReportTaken) {
+			if takenErr := r.checkReportTaken(ctx, report.Id, report.MonthOf, report.WorkerId); takenErr != nil {
+				return nil, takenErr
+			}
+		}
+		return nil, err
+	}
+
+	return toReportResponse(report), nil
+}
+
+// Purge permanently removes the reports that have been in the trash longer
+// than the retention period. Their revision history is kept.
+func (r *ReportServiceImpl) Purge(ctx context.Context) (*response.PurgeResponse, error) {
+	user, err := currentUser(ctx)
+	if err != nil {
+		return nil, err
 	}
-	if query.PerPage <= 0 {
-		query.PerPage = r.paginationConfig.PageLimit
+	if user.Role != model.RoleAdmin {
+		return nil, ErrForbidden
 	}
 
+	retention := r.config.TrashRetention
+	if retention <= 0 {
+		retention = config.DefaultTrashRetention
+	}
+	deletedBefore := time.Now().Add(-retention)
+
+	// Every purged report gets a revision made from this one
+	revision := &model.ReportRevision{
+		Action:    model.ActionPurge,
+		ActorId:   user.Id,
+		Changes:   []*model.FieldChange{},
+		CreatedAt: time.Now(),
+	}
+
+	ids, err := r.reportRepository.Purge(ctx, deletedBefore, revision)
+	if err != nil {
+		return nil, err
+	}
+
+	return &response.PurgeResponse{Purged: len(ids), ReportIds: ids, DeletedBefore: deletedBefore}, nil
+}
+
+func (r *ReportServiceImpl) FindAll(ctx context.Context, query *model.SearchReportQuery) (*model.SearchReportResult, error) {
+	user, err := currentUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// Only return the reports the caller is allowed to see
+	query.Scope = user.ReportScope()
+
+	// Fill in the page and page size the client left out, and cap the size
+	query.Page, query.PerPage = r.paginationConfig.Limit(query.Page, query.PerPage)
+
 	// Fetch the data from the repository using the provided query
 	result, err := r.reportRepository.FindAll(ctx, query)
 	if err != nil {
@@ -118,16 +280,483 @@ func (r *ReportServiceImpl) FindAll(ctx context.Context, query *model.SearchRepo
 	return result, nil
 }
 
+// Search ranks the reports the caller may see by how well their narrative,
+// challenges and prayer requests match query.Q
+func (r *ReportServiceImpl) Search(ctx context.Context, query *model.SearchReportQuery) (*model.ReportSearchResult, error) {
+	if query.Q == "" {
+		return nil, ErrSearchTermMissing
+	}
+
+	user, err := currentUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+	query.Scope = user.ReportScope()
+
+	query.Page, query.PerPage = r.paginationConfig.Limit(query.Page, query.PerPage)
+
+	return r.reportRepository.Search(ctx, query)
+}
+
 func (r *ReportServiceImpl) FindById(ctx context.Context, id int) (*response.ReportResponse, error) {
-	report, err := r.reportRepository.FindById(ctx, id)
+	report, err := r.findReport(ctx, id)
 	if err != nil {
 		return nil, err // Return error if FindById fails
 	}
 
+	return toReportResponse(report), nil
+}
+
+// FindAllForExport returns every report matching the query, without
+// pagination, grouped by worker and ordered within each worker as the query
+// asks or else by month. The workbook has one sheet per worker.
+func (r *ReportServiceImpl) FindAllForExport(ctx context.Context, query *model.SearchReportQuery) ([]*response.ReportResponse, error) {
+	user, err := currentUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	query.Scope = user.ReportScope()
+	query.Page = 1
+	query.PerPage = 0
+	query.Cursor = nil
+	query.SkipTotal = true
+	if len(query.Sort) == 0 {
+		query.Sort = []model.SortField{{Key: "month_of"}}
+	}
+
+	result, err := r.reportRepository.FindAll(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+
+	// A stable sort keeps the requested order within each worker
+	sort.SliceStable(result.Reports, func(i, j int) bool {
+		a, b := result.Reports[i], result.Reports[j]
+		if a.WorkerName != b.WorkerName {
+			return a.WorkerName < b.WorkerName
+		}
+		return a.WorkerId < b.WorkerId
+	})
+
+	reports := make([]*response.ReportResponse, 0, len(result.Reports))
+	for _, report := range result.Reports {
+		reports = append(reports, toReportResponse(report))
+	}
+
+	return reports, nil
+}
+
+// FindPeople follows a person across months: every entry with the same
+// name, whatever its case or spacing, in the reports the caller may see
+func (r *ReportServiceImpl) FindPeople(ctx context.Context, name string, query *model.SearchReportQuery) ([]*model.PersonOccurrence, error) {
+	name = strings.ToLower(strings.Join(strings.Fields(name), " "))
+	if name == "" {
+		return nil, ErrPersonNameMissing
+	}
+
+	user, err := currentUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+	query.Scope = user.ReportScope()
+
+	return r.reportRepository.FindPeople(ctx, name, query)
+}
+
+// AreaSummary consolidates the reports of every church in the area for
+// the month
+func (r *ReportServiceImpl) AreaSummary(ctx context.Context, areaId int, monthOf model.Period) (*model.AreaSummary, error) {
+	user, err := currentUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if !user.CanAccessArea(areaId) {
+		return nil, ErrForbidden
+	}
+
+	area, err := r.organizationRepository.FindById(ctx, model.LevelArea, areaId)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrAreaNotFound
+		}
+		return nil, err
+	}
+
+	result, err := r.reportRepository.FindAll(ctx, &model.SearchReportQuery{
+		MonthOf: monthOf,
+		AreaId:  area.Id,
+		Page:    1,
+		Scope:   user.ReportScope(),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sort.SliceStable(result.Reports, func(i, j int) bool {
+		a, b := result.Reports[i], result.Reports[j]
+		if a.NameOfChurch != b.NameOfChurch {
+			return a.NameOfChurch < b.NameOfChurch
+		}
+		return a.WorkerName < b.WorkerName
+	})
+
+	return model.SummarizeArea(area, monthOf, result.Reports), nil
+}
+
+// Trends returns the monthly average of every requested activity over the
+// range, for the reports the caller is allowed to see
+func (r *ReportServiceImpl) Trends(ctx context.Context, query *model.TrendQuery) (*model.TrendResult, error) {
+	user, err := currentUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(query.Activities) == 0 {
+		query.Activities = model.Activities
+	}
+
+	result, err := r.reportRepository.FindAll(ctx, &model.SearchReportQuery{
+		MonthFrom: query.MonthFrom,
+		MonthTo:   query.MonthTo,
+		WorkerId:  query.WorkerId,
+		ChurchId:  query.ChurchId,
+		AreaId:    query.AreaId,
+		Page:      1,
+		Scope:     user.ReportScope(),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return model.BuildTrends(query, result.Reports), nil
+}
+
+// AnnualReport builds the worker's report for the year from the monthly
+// reports up to and including through
+func (r *ReportServiceImpl) AnnualReport(ctx context.Context, workerId, year int, through model.Period) (*model.AnnualReport, error) {
+	user, err := currentUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if user.Role == model.RoleWorker && user.WorkerId != workerId {
+		return nil, ErrForbidden
+	}
+
+	worker, err := r.findWorker(ctx, workerId)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := r.reportRepository.FindAll(ctx, &model.SearchReportQuery{
+		WorkerId:  worker.Id,
+		MonthFrom: model.NewPeriod(year, time.January),
+		MonthTo:   through,
+		Page:      1,
+		Scope:     user.ReportScope(),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return model.BuildAnnualReport(worker, year, through, result.Reports), nil
+}
+
+// Import creates a report for every row. Rows are independent: a rejected
+// row is listed with its errors and does not stop the others.
+func (r *ReportServiceImpl) Import(ctx context.Context, rows []*request.ReportImportRow) (*response.ImportResponse, error) {
+	if _, err := currentUser(ctx); err != nil {
+		return nil, err
+	}
+
+	result := &response.ImportResponse{Rows: len(rows), Errors: []*response.ImportRowError{}}
+	for _, row := range rows {
+		var validationErr *model.ValidationError
+		if err := r.importRow(ctx, row); errors.As(err, &validationErr) {
+			for _, field := range validationErr.Fields {
+				row.Errors = append(row.Errors, field.Field+": "+field.Message)
+			}
+		} else if err != nil {
+			row.Errors = append(row.Errors, err.Error())
+		}
+
+		if len(row.Errors) > 0 {
+			result.Errors = append(result.Errors, &response.ImportRowError{Row: row.Row, Errors: row.Errors})
+			continue
+		}
+		result.Created++
+	}
+
+	return result, nil
+}
+
+func (r *ReportServiceImpl) importRow(ctx context.Context, row *request.ReportImportRow) error {
+	if len(row.Errors) > 0 || row.Report == nil {
+		return nil
+	}
+
+	// Spreadsheets usually carry the worker's name rather than the id
+	if row.Report.WorkerId == 0 && row.WorkerName != "" {
+		worker, err := r.workerRepository.FindByName(ctx, row.WorkerName)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return fmt.Errorf("%w: %s", ErrWorkerNotFound, row.WorkerName)
+			}
+			return err
+		}
+		row.Report.WorkerId = worker.Id
+	}
+
+	if err := row.Report.Validate(); err != nil {
+		return err
+	}
+
+	return r.Create(ctx, row.Report)
+}
+
+// ImportSheet creates the report read from a workbook, or updates the
+// worker's report for that month when there is one. The changes are always
+// returned; they are saved only when apply is set and nothing failed.
+func (r *ReportServiceImpl) ImportSheet(ctx context.Context, sheet *request.ReportSheetImport, apply bool) (*response.ReportSheetImportResponse, error) {
+	if _, err := currentUser(ctx); err != nil {
+		return nil, err
+	}
+
+	report := sheet.Report
+
+	var worker *model.Worker
+	if sheet.WorkerName == "" {
+		sheet.AddError("worker_name", "must not be empty")
+	} else {
+		found, err := r.workerRepository.FindByName(ctx, sheet.WorkerName)
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			sheet.AddError("worker_name", "no worker is named "+sheet.WorkerName)
+		case err != nil:
+			return nil, err
+		default:
+			worker = found
+			report.WorkerId = worker.Id
+		}
+	}
+
+	church, err := r.findChurchByName(ctx, sheet.AreaName, sheet.ChurchName)
+	if err != nil {
+		if !errors.Is(err, ErrChurchNotFound) {
+			return nil, err
+		}
+		sheet.AddError("name_of_church", err.Error())
+	} else {
+		report.ChurchId = church.Id
+	}
+
+	// An existing report for the worker and month is updated
+	var existing *model.Report
+	if worker != nil && !report.MonthOf.IsZero() {
+		taken, err := r.reportRepository.ReportTaken(ctx, 0, report.MonthOf, worker.Id)
+		if err != nil {
+			return nil, err
+		}
+		if len(taken) > 0 {
+			existing, err = r.findReport(ctx, taken[0].Id)
+			if err != nil {
+				if errors.Is(err, ErrReportNotFound) {
+					return nil, ErrForbidden
+				}
+				return nil, err
+			}
+		}
+	}
+
+	result := &response.ReportSheetImportResponse{Action: "create", Errors: sheet.Errors}
+	if existing != nil {
+		result.Action = "update"
+		result.ReportId = existing.Id
+		result.Version = existing.Version
+	}
+
+	result.Changes, err = reportChanges(existing, sheet, church)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(sheet.Errors) == 0 {
+		var validationErr *model.ValidationError
+		if err := report.Validate(); errors.As(err, &validationErr) {
+			for _, field := range validationErr.Fields {
+				sheet.AddError(field.Field, field.Message)
+			}
+		}
+	}
+	result.Errors = sheet.Errors
+	if result.Errors == nil {
+		result.Errors = []*model.CellError{}
+	}
+
+	if !apply || len(result.Errors) > 0 {
+		return result, nil
+	}
+
+	// The report may have changed since the preview the sheet is applied on
+	if existing != nil {
+		if sheet.Version == 0 {
+			return nil, ErrVersionRequired
+		}
+		err = r.Update(ctx, report.ToUpdate(existing.Id, sheet.Version))
+	} else {
+		err = r.Create(ctx, report)
+	}
+	if err != nil {
+		return nil, err
+	}
+	result.Applied = true
+
+	if existing == nil {
+		taken, err := r.reportRepository.ReportTaken(ctx, 0, report.MonthOf, report.WorkerId)
+		if err != nil {
+			return nil, err
+		}
+		if len(taken) > 0 {
+			result.ReportId = taken[0].Id
+		}
+	}
+
+	if result.ReportId != 0 {
+		saved, err := r.reportRepository.FindById(ctx, result.ReportId)
+		if err != nil {
+			return nil, err
+		}
+		result.Version = saved.Version
+	}
+
+	return result, nil
+}
+
+// findChurchByName finds a church by name, within the named area when one
+// is given
+func (r *ReportServiceImpl) findChurchByName(ctx context.Context, areaName, churchName string) (*model.OrgUnit, error) {
+	if churchName == "" {
+		return nil, fmt.Errorf("%w: the name of the church is empty", ErrChurchNotFound)
+	}
+
+	churches, err := r.organizationRepository.FindAll(ctx, &model.SearchOrgUnitQuery{Level: model.LevelChurch, Name: churchName})
+	if err != nil {
+		return nil, err
+	}
+
+	var matches []*model.OrgUnit
+	for _, church := range churches {
+		if strings.EqualFold(church.Name, churchName) && (areaName == "" || strings.EqualFold(church.ParentName, areaName)) {
+			matches = append(matches, church)
+		}
+	}
+
+	switch {
+	case len(matches) == 0 && areaName != "":
+		return nil, fmt.Errorf("%w: no church named %s in %s", ErrChurchNotFound, churchName, areaName)
+	case len(matches) == 0:
+		return nil, fmt.Errorf("%w: no church named %s", ErrChurchNotFound, churchName)
+	case len(matches) > 1:
+		return nil, fmt.Errorf("%w: several churches are named %s; fill in the area of assignment", ErrChurchNotFound, churchName)
+	}
+
+	return matches[0], nil
+}
+
+// reportChanges lists the fields an import would change, comparing their
+// JSON values. Without an existing report every filled-in field is new.
+func reportChanges(existing *model.Report, sheet *request.ReportSheetImport, church *model.OrgUnit) ([]*model.FieldChange, error) {
+	oldFields := map[string]interface{}{}
+	if existing != nil {
+		if err := remarshal(toReportResponse(existing), &oldFields); err != nil {
+			return nil, err
+		}
+	}
+
+	newFields := map[string]interface{}{}
+	if err := remarshal(sheet.Report, &newFields); err != nil {
+		return nil, err
+	}
+	if emptyToNil(newFields["currency"]) == nil {
+		newFields["currency"] = model.DefaultCurrency
+		if existing != nil {
+			newFields["currency"] = existing.Currency
+		}
+	}
+	newFields["worker_name"] = sheet.WorkerName
+	newFields["area_of_assignment"] = sheet.AreaName
+	newFields["name_of_church"] = sheet.ChurchName
+	if church != nil {
+		newFields["area_of_assignment"] = church.ParentName
+		newFields["name_of_church"] = church.Name
+	}
+
+	return diffFields(oldFields, newFields, reportFieldKeys()), nil
+}
+
+// reportFieldKeys lists the fields a worker fills in, in form order
+func reportFieldKeys() []string {
+	keys := []string{"month_of", "worker_name", "area_of_assignment", "name_of_church"}
+	for _, activity := range model.Activities {
+		keys = append(keys, activity.Key)
+	}
+	keys = append(keys, "tithes_and_offerings", "currency")
+	return append(keys, "people", "narrative_report", "challenges_and_problem_encountered", "prayer_request")
+}
+
+// currencyOrDefault keeps the given currency when the request names none
+func currencyOrDefault(currency, fallback string) string {
+	if currency == "" {
+		return fallback
+	}
+	return currency
+}
+
+// diffFields compares the JSON values of the given keys
+func diffFields(oldFields, newFields map[string]interface{}, keys []string) []*model.FieldChange {
+	changes := []*model.FieldChange{}
+	for _, key := range keys {
+		oldValue, newValue := emptyToNil(oldFields[key]), emptyToNil(newFields[key])
+		if fmt.Sprint(oldValue) != fmt.Sprint(newValue) {
+			changes = append(changes, &model.FieldChange{Field: key, Old: oldValue, New: newValue})
+		}
+	}
+	return changes
+}
+
+func remarshal(value interface{}, dst *map[string]interface{}) error {
+	body, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(body, dst)
+}
+
+// emptyToNil treats missing, empty and null values alike
+func emptyToNil(value interface{}) interface{} {
+	switch v := value.(type) {
+	case string:
+		if v == "" {
+			return nil
+		}
+	case []interface{}:
+		if len(v) == 0 {
+			return nil
+		}
+	}
+	return value
+}
+
+func toReportResponse(report *model.Report) *response.ReportResponse {
 	reportResp := &response.ReportResponse{
 		Id:                              report.Id,
 		MonthOf:                         report.MonthOf,
+		WorkerId:                        report.WorkerId,
 		WorkerName:                      report.WorkerName,
+		ChurchId:                        report.ChurchId,
+		AreaId:                          report.AreaId,
 		AreaOfAssignment:                report.AreaOfAssignment,
 		NameOfChurch:                    report.NameOfChurch,
 		WorshipService:                  report.WorshipService,
@@ -145,16 +774,25 @@ func (r *ReportServiceImpl) FindById(ctx context.Context, id int) (*response.Rep
 		Others:                          report.Others,
 		FamilyDays:                      report.FamilyDays,
 		TithesAndOfferings:              report.TithesAndOfferings,
+		Currency:                        report.Currency,
 		HomeVisited:                     report.HomeVisited,
 		BibleStudyOrGroupLed:            report.BibleStudyOrGroupLed,
 		SermonOrMessagePreached:         report.SermonOrMessagePreached,
 		PersonNewlyContacted:            report.PersonNewlyContacted,
 		PersonFollowedUp:                report.PersonFollowedUp,
 		PersonLedToChrist:               report.PersonLedToChrist,
-		Names:                           report.Names,
+		People:                          report.People,
 		NarrativeReport:                 report.NarrativeReport,
 		ChallengesAndProblemEncountered: report.ChallengesAndProblemEncountered,
 		PrayerRequest:                   report.PrayerRequest,
+		Status:                          report.Status,
+		ReviewNote:                      report.ReviewNote,
+		SubmittedAt:                     report.SubmittedAt,
+		ReviewedAt:                      report.ReviewedAt,
+		ReviewedBy:                      report.ReviewedBy,
+		DeletedAt:                       report.DeletedAt,
+		DeletedBy:                       report.DeletedBy,
+		Version:                         report.Version,
 		CreatedAt:                       report.CreatedAt,
 		UpdatedAt:                       report.UpdatedAt,
 	}
@@ -174,7 +812,8 @@ func (r *ReportServiceImpl) FindById(ctx context.Context, id int) (*response.Rep
 	reportResp.LeadershipTrainingAvg = model.CalculateAverage(report.LeadershipTraining)
 	reportResp.OthersAvg = model.CalculateAverage(report.Others)
 	reportResp.FamilyDaysAvg = model.CalculateAverage(report.FamilyDays)
-	reportResp.TithesAndOfferingsAvg = model.CalculateAverage(report.TithesAndOfferings)
+	reportResp.TithesAndOfferingsTotal = model.SumMoney(report.TithesAndOfferings)
+	reportResp.PeopleCounts = model.CountPeople(report.People)
 	reportResp.HomeVisitedAvg = model.CalculateAverage(report.HomeVisited)
 	reportResp.BibleStudyOrGroupLedAvg = model.CalculateAverage(report.BibleStudyOrGroupLed)
 	reportResp.SermonOrMessagePreachedAvg = model.CalculateAverage(report.SermonOrMessagePreached)
@@ -182,36 +821,60 @@ func (r *ReportServiceImpl) FindById(ctx context.Context, id int) (*response.Rep
 	reportResp.PersonFollowedUpAvg = model.CalculateAverage(report.PersonFollowedUp)
 	reportResp.PersonLedToChristAvg = model.CalculateAverage(report.PersonLedToChrist)
 
-	return reportResp, nil
+	return reportResp
 }
 func (r *ReportServiceImpl) Update(ctx context.Context, request *request.ReportUpdateRequest) error {
-	// result, err := r.reportRepository.ReportTaken(ctx, request.Id, request.MonthOf, request.WorkerName)
-	// if err != nil {
-	// 	return err
-	// }
+	// Retrieve the existing report by ID
+	existingReport, err := r.findReport(ctx, request.Id)
+	if err != nil {
+		return err
+	}
 
-	// // Check if the report exists
+	// The caller must also be allowed to own the report after the change
+	user, err := currentUser(ctx)
+	if err != nil {
+		return err
+	}
 
-	// if len(result) == 0 {
-	// 	return errors.New("report already taken")
-	// }
+	// Someone else saved the report after the client read it
+	if !request.MatchesVersion(existingReport.Version) {
+		return ErrReportModified
+	}
 
-	// // Check if there is any report with the same month and worker name that is not the current report
-	// if len(result) > 1 || (len(result) == 1 && result[0].Id != request.Id) {
-	// 	return errors.New("report already taken")
-	// }
+	// Submitted and approved reports are frozen except for admins
+	if !existingReport.Status.Editable() && user.Role != model.RoleAdmin {
+		return fmt.Errorf("%w: the report is %s", ErrReportLocked, existingReport.Status)
+	}
 
-	// Retrieve the existing report by ID
-	existingReport, err := r.reportRepository.FindById(ctx, request.Id)
+	church, err := r.findChurch(ctx, request.ChurchId)
+	if err != nil {
+		return err
+	}
+	if !user.CanAccessReport(request.WorkerId, church.ParentId) {
+		return ErrForbidden
+	}
+
+	worker, err := r.findWorker(ctx, request.WorkerId)
 	if err != nil {
 		return err
 	}
 
+	// Moving the report to another worker or month must not collide with an existing one
+	if err := r.checkReportTaken(ctx, request.Id, request.MonthOf, request.WorkerId); err != nil {
+		return err
+	}
+
+	// Keep the report as it was for the revision history
+	before := *existingReport
+
 	// Update the fields of the existing report entity with request data
 	existingReport.MonthOf = request.MonthOf
-	existingReport.WorkerName = request.WorkerName
-	existingReport.AreaOfAssignment = request.AreaOfAssignment
-	existingReport.NameOfChurch = request.NameOfChurch
+	existingReport.WorkerId = worker.Id
+	existingReport.WorkerName = worker.Name
+	existingReport.ChurchId = church.Id
+	existingReport.AreaId = church.ParentId
+	existingReport.AreaOfAssignment = church.ParentName
+	existingReport.NameOfChurch = church.Name
 	existingReport.WorshipService = request.WorshipService
 	existingReport.SundaySchool = request.SundaySchool
 	existingReport.PrayerMeetings = request.PrayerMeetings
@@ -227,25 +890,384 @@ func (r *ReportServiceImpl) Update(ctx context.Context, request *request.ReportU
 	existingReport.Others = request.Others
 	existingReport.FamilyDays = request.FamilyDays
 	existingReport.TithesAndOfferings = request.TithesAndOfferings
+	existingReport.Currency = currencyOrDefault(request.Currency, existingReport.Currency)
 	existingReport.HomeVisited = request.HomeVisited
 	existingReport.BibleStudyOrGroupLed = request.BibleStudyOrGroupLed
 	existingReport.SermonOrMessagePreached = request.SermonOrMessagePreached
 	existingReport.PersonNewlyContacted = request.PersonNewlyContacted
 	existingReport.PersonFollowedUp = request.PersonFollowedUp
 	existingReport.PersonLedToChrist = request.PersonLedToChrist
-	existingReport.Names = request.Names
+	existingReport.People = request.People
 	existingReport.NarrativeReport = request.NarrativeReport
 	existingReport.ChallengesAndProblemEncountered = request.ChallengesAndProblemEncountered
 	existingReport.PrayerRequest = request.PrayerRequest
 
-	err = r.reportRepository.Update(ctx, existingReport)
+	revision, err := r.revision(ctx, model.ActionUpdate, &before, existingReport)
+	if err != nil {
+		return err
+	}
+
+	err = r.reportRepository.Update(ctx, existingReport, revision)
+	if err != nil {
+		if errors.Is(err, repository.ErrReportTaken) {
+			if takenErr := r.checkReportTaken(ctx, existingReport.Id, existingReport.MonthOf, existingReport.WorkerId); takenErr != nil {
+				return takenErr
+			}
+		}
+		if errors.Is(err, repository.ErrReportModified) {
+			return ErrReportModified
+		}
+		return err
+	}
+
+	return nil
+}
+
+// Patch applies a JSON Merge Patch to a report. Only the fields in the
+// patch change; the merged report is validated and saved like a full update,
+// including the version check.
+func (r *ReportServiceImpl) Patch(ctx context.Context, id, version int, patch map[string]interface{}) (*response.ReportResponse, error) {
+	existingReport, err := r.findReport(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	patchable := patchableFields()
+
+	var unknown []string
+	for key := range patch {
+		if !patchable[key] {
+			unknown = append(unknown, key)
+		}
+	}
+	if len(unknown) > 0 {
+		sort.Strings(unknown)
+		return nil, fmt.Errorf("%w: cannot change %s", ErrInvalidPatch, strings.Join(unknown, ", "))
+	}
+
+	current := map[string]interface{}{}
+	if err := remarshal(existingReport, &current); err != nil {
+		return nil, err
+	}
+	for key := range current {
+		if !patchable[key] {
+			delete(current, key)
+		}
+	}
+
+	merged, err := json.Marshal(utils.MergePatch(current, patch))
+	if err != nil {
+		return nil, err
+	}
+
+	var updateRequest request.ReportUpdateRequest
+	if err := json.Unmarshal(merged, &updateRequest); err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidPatch, err.Error())
+	}
+	updateRequest.Id = existingReport.Id
+	updateRequest.Version = version
+
+	if err := updateRequest.Validate(); err != nil {
+		return nil, err
+	}
+
+	if err := r.Update(ctx, &updateRequest); err != nil {
+		return nil, err
+	}
+
+	return r.FindById(ctx, id)
+}
+
+// patchableFields are the JSON fields of ReportUpdateRequest a patch may set
+func patchableFields() map[string]bool {
+	fields := map[string]bool{"worker_id": true, "church_id": true}
+	for _, key := range reportFieldKeys() {
+		fields[key] = true
+	}
+	delete(fields, "worker_name")
+	delete(fields, "area_of_assignment")
+	delete(fields, "name_of_church")
+	return fields
+}
+
+// Submit hands a draft or returned report in for review. This is where the
+// report must be complete.
+func (r *ReportServiceImpl) Submit(ctx context.Context, id int) (*response.ReportResponse, error) {
+	report, err := r.findReport(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := checkTransition(report, model.StatusSubmitted); err != nil {
+		return nil, err
+	}
+
+	if err := report.ValidateForSubmit(); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrReportIncomplete, err)
+	}
+
+	before := *report
+
+	now := time.Now()
+	report.Status = model.StatusSubmitted
+	report.SubmittedAt = &now
+	report.UpdatedAt = now
+
+	revision, err := r.revision(ctx, model.ActionSubmit, &before, report)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := r.reportRepository.UpdateStatus(ctx, report, revision); err != nil {
+		if errors.Is(err, repository.ErrReportModified) {
+			return nil, ErrReportModified
+		}
+		return nil, err
+	}
+
+	return toReportResponse(report), nil
+}
+
+// Approve accepts a submitted report
+func (r *ReportServiceImpl) Approve(ctx context.Context, id int, request *request.ReportReviewRequest) (*response.ReportResponse, error) {
+	return r.review(ctx, id, model.StatusApproved, model.ActionApprove, request.Note)
+}
+
+// Return sends a submitted report back to the worker with a note
+func (r *ReportServiceImpl) Return(ctx context.Context, id int, request *request.ReportReviewRequest) (*response.ReportResponse, error) {
+	return r.review(ctx, id, model.StatusReturned, model.ActionReturn, request.Note)
+}
+
+func (r *ReportServiceImpl) review(ctx context.Context, id int, status model.ReportStatus, action model.RevisionAction, note string) (*response.ReportResponse, error) {
+	user, err := currentUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// Workers never review, not even their own reports
+	if user.Role == model.RoleWorker {
+		return nil, ErrForbidden
+	}
+
+	report, err := r.findReport(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := checkTransition(report, status); err != nil {
+		return nil, err
+	}
+
+	before := *report
+
+	now := time.Now()
+	report.Status = status
+	report.ReviewNote = note
+	report.ReviewedAt = &now
+	report.ReviewedBy = user.Id
+	report.UpdatedAt = now
+
+	revision, err := r.revision(ctx, action, &before, report)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := r.reportRepository.UpdateStatus(ctx, report, revision); err != nil {
+		if errors.Is(err, repository.ErrReportModified) {
+			return nil, ErrReportModified
+		}
+		return nil, err
+	}
+
+	return toReportResponse(report), nil
+}
+
+func checkTransition(report *model.Report, next model.ReportStatus) error {
+	if !report.Status.CanTransition(next) {
+		return fmt.Errorf("%w: a %s report cannot become %s", ErrInvalidTransition, report.Status, next)
+	}
+	return nil
+}
+
+// History lists the revisions of a report, oldest first. Reports in the
+// trash keep their history; admins can also read that of purged reports.
+func (r *ReportServiceImpl) History(ctx context.Context, reportId int) ([]*model.ReportRevision, error) {
+	purged, err := r.checkHistoryAccess(ctx, reportId)
+	if err != nil {
+		return nil, err
+	}
+
+	revisions, err := r.revisionRepository.FindByReport(ctx, reportId)
+	if err != nil {
+		return nil, err
+	}
+
+	// Reports saved before revisions were recorded have an empty history
+	if purged && len(revisions) == 0 {
+		return nil, ErrReportNotFound
+	}
+
+	return revisions, nil
+}
+
+// Revision returns one revision together with the report as it stood then
+func (r *ReportServiceImpl) Revision(ctx context.Context, reportId, revisionId int) (*response.ReportRevisionResponse, error) {
+	if _, err := r.checkHistoryAccess(ctx, reportId); err != nil {
+		return nil, err
+	}
+
+	revision, err := r.revisionRepository.FindById(ctx, reportId, revisionId)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrRevisionNotFound
+		}
+		return nil, err
+	}
+
+	revisionResp := &response.ReportRevisionResponse{ReportRevision: revision}
+	if revision.Snapshot != nil {
+		revisionResp.Report = toReportResponse(revision.Snapshot)
+	}
+
+	return revisionResp, nil
+}
+
+// checkHistoryAccess lets through callers who can see the report, live or
+// in the trash. Reports that no longer exist are left to admins and
+// reported as purged.
+func (r *ReportServiceImpl) checkHistoryAccess(ctx context.Context, reportId int) (bool, error) {
+	user, err := currentUser(ctx)
+	if err != nil {
+		return false, err
+	}
+
+	_, err = r.findReport(ctx, reportId)
+	if !errors.Is(err, ErrReportNotFound) {
+		return false, err
+	}
+
+	deleted, err := r.reportRepository.FindDeletedById(ctx, reportId)
+	switch {
+	case err == nil && user.CanAccessReport(deleted.WorkerId, deleted.AreaId):
+		return false, nil
+	case err != nil && !errors.Is(err, sql.ErrNoRows):
+		return false, err
+	case err != nil && user.Role == model.RoleAdmin:
+		return true, nil
+	}
+
+	return false, ErrReportNotFound
+}
+
+// revision builds the revision a report write records alongside the
+// change. before is nil for a create and after is nil for a delete.
+func (r *ReportServiceImpl) revision(ctx context.Context, action model.RevisionAction, before, after *model.Report) (*model.ReportRevision, error) {
+	user, err := currentUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	oldFields := map[string]interface{}{}
+	if before != nil {
+		if err := remarshal(toReportResponse(before), &oldFields); err != nil {
+			return nil, err
+		}
+	}
+
+	newFields := map[string]interface{}{}
+	if after != nil {
+		if err := remarshal(toReportResponse(after), &newFields); err != nil {
+			return nil, err
+		}
+	}
+
+	snapshot := after
+	if snapshot == nil {
+		snapshot = before
+	}
+
+	return &model.ReportRevision{
+		ReportId:  snapshot.Id,
+		Action:    action,
+		ActorId:   user.Id,
+		Changes:   diffFields(oldFields, newFields, append(reportFieldKeys(), "status", "review_note", "deleted_at")),
+		Snapshot:  snapshot,
+		CreatedAt: time.Now(),
+	}, nil
+}
+
+// findReport loads a report and hides it from callers outside its scope
+func (r *ReportServiceImpl) findReport(ctx context.Context, id int) (*model.Report, error) {
+	user, err := currentUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	report, err := r.reportRepository.FindById(ctx, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrReportNotFound
+		}
+		return nil, err
+	}
+
+	if !user.CanAccessReport(report.WorkerId, report.AreaId) {
+		return nil, ErrReportNotFound
+	}
+
+	return report, nil
+}
+
+// checkReportTaken returns a ReportAlreadySubmittedError when another report
+// already exists for the worker and month
+func (r *ReportServiceImpl) checkReportTaken(ctx context.Context, id int, monthOf model.Period, workerId int) error {
+	taken, err := r.reportRepository.ReportTaken(ctx, id, monthOf, workerId)
 	if err != nil {
 		return err
 	}
 
+	if len(taken) > 0 {
+		return &ReportAlreadySubmittedError{
+			ReportId:   taken[0].Id,
+			MonthOf:    taken[0].MonthOf,
+			WorkerName: taken[0].WorkerName,
+		}
+	}
+
 	return nil
 }
 
+func (r *ReportServiceImpl) findWorker(ctx context.Context, workerId int) (*model.Worker, error) {
+	worker, err := r.workerRepository.FindById(ctx, workerId)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrWorkerNotFound
+		}
+		return nil, err
+	}
+	return worker, nil
+}
+
+// findChurch loads a church; its parent is the area of assignment
+func (r *ReportServiceImpl) findChurch(ctx context.Context, churchId int) (*model.OrgUnit, error) {
+	church, err := r.organizationRepository.FindById(ctx, model.LevelChurch, churchId)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrChurchNotFound
+		}
+		return nil, err
+	}
+	return church, nil
+}
+
+func currentUser(ctx context.Context) (*model.User, error) {
+	user, ok := model.UserFromContext(ctx)
+	if !ok {
+		return nil, ErrForbidden
+	}
+	return user, nil
+}
+
 func (r *ReportServiceImpl) ExportReportToExcel(ctx context.Context, id int) (string, error) {
 	reportResp, err := r.FindById(ctx, id)
 	if err != nil {
@@ -258,7 +1280,7 @@ func (r *ReportServiceImpl) ExportReportToExcel(ctx context.Context, id int) (st
 	f.DeleteSheet("Sheet1")
 
 	// Headers
-	headers := []string{"ID", "Month Of", "Worker Name", "Area Of Assignment", "Name Of Church", "Worship Service", "Sunday School", "Prayer Meetings", "Bible Studies", "Mens Fellowships", "Womens Fellowships", "Youth Fellowships", "Child Fellowships", "Outreach", "Training Or Seminars", "Leadership Conferences", "Leadership Training", "Others", "Family Days", "Tithes And Offerings", "Home Visited", "Bible Study Or Group Led", "Sermon Or Message Preached", "Person Newly Contacted", "Person Followed Up", "Person Led To Christ", "Names", "Narrative Report", "Challenges And Problem Encountered", "Prayer Request", "Created At", "Updated At", "Worship Service Avg", "Sunday School Avg", "Prayer Meetings Avg", "Bible Studies Avg", "Mens Fellowships Avg", "Womens Fellowships Avg", "Youth Fellowships Avg", "Child Fellowships Avg", "Outreach Avg", "Training Or Seminars Avg", "Leadership Conferences Avg", "Leadership Training Avg", "Others Avg", "Family Days Avg", "Tithes And Offerings Avg", "Home Visited Avg", "Bible Study Or Group Led Avg", "Sermon Or Message Preached Avg", "Person Newly Contacted Avg", "Person Followed Up Avg", "Person Led To Christ Avg"}
+	headers := []string{"ID", "Month Of", "Worker Name", "Area Of Assignment", "Name Of Church", "Worship Service", "Sunday School", "Prayer Meetings", "Bible Studies", "Mens Fellowships", "Womens Fellowships", "Youth Fellowships", "Child Fellowships", "Outreach", "Training Or Seminars", "Leadership Conferences", "Leadership Training", "Others", "Family Days", "Tithes And Offerings", "Home Visited", "Bible Study Or Group Led", "Sermon Or Message Preached", "Person Newly Contacted", "Person Followed Up", "Person Led To Christ", "People", "Narrative Report", "Challenges And Problem Encountered", "Prayer Request", "Created At", "Updated At", "Worship Service Avg", "Sunday School Avg", "Prayer Meetings Avg", "Bible Studies Avg", "Mens Fellowships Avg", "Womens Fellowships Avg", "Youth Fellowships Avg", "Child Fellowships Avg", "Outreach Avg", "Training Or Seminars Avg", "Leadership Conferences Avg", "Leadership Training Avg", "Others Avg", "Family Days Avg", "Tithes And Offerings Total", "Home Visited Avg", "Bible Study Or Group Led Avg", "Sermon Or Message Preached Avg", "Person Newly Contacted Avg", "Person Followed Up Avg", "Person Led To Christ Avg"}
 
 	for col, header := range headers {
 		cell, _ := excelize.CoordinatesToCellName(col+1, 1)
@@ -267,9 +1289,9 @@ func (r *ReportServiceImpl) ExportReportToExcel(ctx context.Context, id int) (st
 
 	// Values
 	values := []interface{}{
-		reportResp.Id, reportResp.MonthOf, reportResp.WorkerName, reportResp.AreaOfAssignment, reportResp.NameOfChurch,
-		reportResp.WorshipService, reportResp.SundaySchool, reportResp.PrayerMeetings, reportResp.BibleStudies, reportResp.MensFellowships, reportResp.WomensFellowships, reportResp.YouthFellowships, reportResp.ChildFellowships, reportResp.Outreach, reportResp.TrainingOrSeminars, reportResp.LeadershipConferences, reportResp.LeadershipTraining, reportResp.Others, reportResp.FamilyDays, reportResp.TithesAndOfferings, reportResp.HomeVisited, reportResp.BibleStudyOrGroupLed, reportResp.SermonOrMessagePreached, reportResp.PersonNewlyContacted, reportResp.PersonFollowedUp, reportResp.PersonLedToChrist, reportResp.Names, reportResp.NarrativeReport, reportResp.ChallengesAndProblemEncountered, reportResp.PrayerRequest, reportResp.CreatedAt, reportResp.UpdatedAt,
-		reportResp.WorshipServiceAvg, reportResp.SundaySchoolAvg, reportResp.PrayerMeetingsAvg, reportResp.BibleStudiesAvg, reportResp.MensFellowshipsAvg, reportResp.WomensFellowshipsAvg, reportResp.YouthFellowshipsAvg, reportResp.ChildFellowshipsAvg, reportResp.OutreachAvg, reportResp.TrainingOrSeminarsAvg, reportResp.LeadershipConferencesAvg, reportResp.LeadershipTrainingAvg, reportResp.OthersAvg, reportResp.FamilyDaysAvg, reportResp.TithesAndOfferingsAvg, reportResp.HomeVisitedAvg, reportResp.BibleStudyOrGroupLedAvg, reportResp.SermonOrMessagePreachedAvg, reportResp.PersonNewlyContactedAvg, reportResp.PersonFollowedUpAvg, reportResp.PersonLedToChristAvg,
+		reportResp.Id, reportResp.MonthOf.Label(), reportResp.WorkerName, reportResp.AreaOfAssignment, reportResp.NameOfChurch,
+		reportResp.WorshipService, reportResp.SundaySchool, reportResp.PrayerMeetings, reportResp.BibleStudies, reportResp.MensFellowships, reportResp.WomensFellowships, reportResp.YouthFellowships, reportResp.ChildFellowships, reportResp.Outreach, reportResp.TrainingOrSeminars, reportResp.LeadershipConferences, reportResp.LeadershipTraining, reportResp.Others, reportResp.FamilyDays, fmt.Sprint(reportResp.TithesAndOfferings), reportResp.HomeVisited, reportResp.BibleStudyOrGroupLed, reportResp.SermonOrMessagePreached, reportResp.PersonNewlyContacted, reportResp.PersonFollowedUp, reportResp.PersonLedToChrist, utils.FormatPeople(reportResp.People), reportResp.NarrativeReport, reportResp.ChallengesAndProblemEncountered, reportResp.PrayerRequest, reportResp.CreatedAt, reportResp.UpdatedAt,
+		reportResp.WorshipServiceAvg, reportResp.SundaySchoolAvg, reportResp.PrayerMeetingsAvg, reportResp.BibleStudiesAvg, reportResp.MensFellowshipsAvg, reportResp.WomensFellowshipsAvg, reportResp.YouthFellowshipsAvg, reportResp.ChildFellowshipsAvg, reportResp.OutreachAvg, reportResp.TrainingOrSeminarsAvg, reportResp.LeadershipConferencesAvg, reportResp.LeadershipTrainingAvg, reportResp.OthersAvg, reportResp.FamilyDaysAvg, reportResp.TithesAndOfferingsTotal.String(), reportResp.HomeVisitedAvg, reportResp.BibleStudyOrGroupLedAvg, reportResp.SermonOrMessagePreachedAvg, reportResp.PersonNewlyContactedAvg, reportResp.PersonFollowedUpAvg, reportResp.PersonLedToChristAvg,
 	}
 
 	for col, value := range values {
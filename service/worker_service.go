@@ -0,0 +1,17 @@
+package service
+
+import (
+	"context"
+	"reports/data/request"
+	"reports/model"
+)
+
+type WorkerService interface {
+	Create(ctx context.Context, request *request.WorkerCreateRequest) (*model.Worker, error)
+	Update(ctx context.Context, request *request.WorkerUpdateRequest) (*model.Worker, error)
+	Delete(ctx context.Context, workerId int) error
+	FindById(ctx context.Context, workerId int) (*model.Worker, error)
+	FindAll(ctx context.Context, query *model.SearchWorkerQuery) (*model.SearchWorkerResult, error)
+	FindDuplicates(ctx context.Context) ([]*model.DuplicateWorkers, error)
+	Merge(ctx context.Context, request *request.WorkerMergeRequest) (*model.Worker, error)
+}
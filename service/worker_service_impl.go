@@ -0,0 +1,193 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"reports/config"
+	"reports/data/request"
+	"reports/model"
+	"reports/repository"
+	"reports/utils"
+	"time"
+)
+
+type WorkerServiceImpl struct {
+	workerRepository repository.WorkerRepository
+	paginationConfig config.PaginationConfig
+}
+
+func NewWorkerServiceImpl(workerRepository repository.WorkerRepository, paginationConfig config.PaginationConfig) WorkerService {
+	return &WorkerServiceImpl{workerRepository: workerRepository, paginationConfig: paginationConfig}
+}
+
+func (w *WorkerServiceImpl) Create(ctx context.Context, request *request.WorkerCreateRequest) (*model.Worker, error) {
+	now := time.Now()
+	worker := model.Worker{
+		Name:      request.Name,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+
+	if err := w.workerRepository.Save(ctx, &worker); err != nil {
+		return nil, mapWorkerError(err)
+	}
+
+	return &worker, nil
+}
+
+func (w *WorkerServiceImpl) Update(ctx context.Context, request *request.WorkerUpdateRequest) (*model.Worker, error) {
+	worker, err := w.FindById(ctx, request.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	worker.Name = request.Name
+	worker.UpdatedAt = time.Now()
+
+	if err := w.workerRepository.Update(ctx, worker); err != nil {
+		return nil, mapWorkerError(err)
+	}
+
+	return worker, nil
+}
+
+func (w *WorkerServiceImpl) Delete(ctx context.Context, workerId int) error {
+	worker, err := w.FindById(ctx, workerId)
+	if err != nil {
+		return err
+	}
+
+	if err := w.workerRepository.Delete(ctx, worker.Id); err != nil {
+		return mapWorkerError(err)
+	}
+
+	return nil
+}
+
+func (w *WorkerServiceImpl) FindById(ctx context.Context, workerId int) (*model.Worker, error) {
+	worker, err := w.workerRepository.FindById(ctx, workerId)
+	if err != nil {
+		return nil, mapWorkerError(err)
+	}
+
+	return worker, nil
+}
+
+func (w *WorkerServiceImpl) FindAll(ctx context.Context, query *model.SearchWorkerQuery) (*model.SearchWorkerResult, error) {
+	// Fill in the page and page size the client left out, and cap the size
+	query.Page, query.PerPage = w.paginationConfig.Limit(query.Page, query.PerPage)
+
+	return w.workerRepository.FindAll(ctx, query)
+}
+
+// FindDuplicates groups workers whose names are likely the same person:
+// identical once honorifics, punctuation and word order are ignored, or
+// within a small edit distance of each other
+func (w *WorkerServiceImpl) FindDuplicates(ctx context.Context) ([]*model.DuplicateWorkers, error) {
+	all, err := w.workerRepository.FindAll(ctx, &model.SearchWorkerQuery{Page: 1})
+	if err != nil {
+		return nil, err
+	}
+
+	workers := all.Workers
+	normalized := make([]string, len(workers))
+	keys := make([]string, len(workers))
+	for i, worker := range workers {
+		normalized[i] = utils.NormalizeName(worker.Name)
+		keys[i] = utils.SortedNameKey(worker.Name)
+	}
+
+	// Union-find over every pair of similar names
+	parent := make([]int, len(workers))
+	for i := range parent {
+		parent[i] = i
+	}
+	var find func(int) int
+	find = func(i int) int {
+		if parent[i] != i {
+			parent[i] = find(parent[i])
+		}
+		return parent[i]
+	}
+
+	for i := range workers {
+		for j := i + 1; j < len(workers); j++ {
+			if keys[i] == keys[j] || similarNames(normalized[i], normalized[j]) {
+				parent[find(j)] = find(i)
+			}
+		}
+	}
+
+	groups := map[int]*model.DuplicateWorkers{}
+	var result []*model.DuplicateWorkers
+	for i, worker := range workers {
+		root := find(i)
+		group, ok := groups[root]
+		if !ok {
+			group = &model.DuplicateWorkers{Key: keys[root]}
+			groups[root] = group
+			result = append(result, group)
+		}
+		group.Workers = append(group.Workers, worker)
+	}
+
+	duplicates := []*model.DuplicateWorkers{}
+	for _, group := range result {
+		if len(group.Workers) > 1 {
+			duplicates = append(duplicates, group)
+		}
+	}
+
+	return duplicates, nil
+}
+
+func (w *WorkerServiceImpl) Merge(ctx context.Context, request *request.WorkerMergeRequest) (*model.Worker, error) {
+	if _, err := w.FindById(ctx, request.TargetId); err != nil {
+		return nil, err
+	}
+
+	for _, id := range request.SourceIds {
+		if _, err := w.FindById(ctx, id); err != nil {
+			return nil, fmt.Errorf("worker %d: %w", id, err)
+		}
+	}
+
+	if err := w.workerRepository.Merge(ctx, request.TargetId, request.SourceIds); err != nil {
+		return nil, mapWorkerError(err)
+	}
+
+	return w.FindById(ctx, request.TargetId)
+}
+
+// similarNames allows one typo in short names and two in longer ones
+func similarNames(a, b string) bool {
+	shortest := min(len(a), len(b))
+	if shortest < 4 {
+		return false
+	}
+
+	limit := 1
+	if shortest >= 8 {
+		limit = 2
+	}
+
+	return utils.Levenshtein(a, b) <= limit
+}
+
+func mapWorkerError(err error) error {
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+		return ErrWorkerNotFound
+	case errors.Is(err, repository.ErrWorkerNameTaken):
+		return ErrWorkerNameTaken
+	case errors.Is(err, repository.ErrWorkerInUse):
+		return ErrWorkerInUse
+	case errors.Is(err, repository.ErrWorkerMergedIntoItself):
+		return ErrWorkerMergeSelf
+	case errors.Is(err, repository.ErrReportTaken):
+		return fmt.Errorf("%w (%v)", ErrWorkerMergeConflict, err)
+	}
+	return err
+}
@@ -0,0 +1,251 @@
+package utils
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"reports/data/request"
+	"reports/data/response"
+	"reports/model"
+	"strconv"
+	"strings"
+)
+
+// csvWeeks is the number of week columns written for every activity
+const csvWeeks = 5
+
+// ReportCSVHeader returns the columns of a report CSV file. Every weekly
+// array is flattened into <activity>_week_1 .. <activity>_week_5, and so are
+// the tithes and offerings, written as decimals in the currency column. The
+// people reached share one column, as written by FormatPeople.
+func ReportCSVHeader() []string {
+	header := []string{"id", "month_of", "worker_id", "worker_name", "church_id", "area_of_assignment", "name_of_church"}
+	for _, activity := range model.Activities {
+		for week := 1; week <= csvWeeks; week++ {
+			header = append(header, csvWeekColumn(activity.Key, week))
+		}
+	}
+	for week := 1; week <= csvWeeks; week++ {
+		header = append(header, csvWeekColumn("tithes_and_offerings", week))
+	}
+	return append(header, "currency", "people", "narrative_report", "challenges_and_problem_encountered", "prayer_request")
+}
+
+func csvWeekColumn(key string, week int) string {
+	return key + "_week_" + strconv.Itoa(week)
+}
+
+// WriteReportsCSV writes the header and one line per report
+func WriteReportsCSV(w io.Writer, reports []*response.ReportResponse) error {
+	writer := csv.NewWriter(w)
+	if err := writer.Write(ReportCSVHeader()); err != nil {
+		return err
+	}
+
+	for _, report := range reports {
+		record := []string{
+			strconv.Itoa(report.Id),
+			report.MonthOf.String(),
+			strconv.Itoa(report.WorkerId),
+			report.WorkerName,
+			strconv.Itoa(report.ChurchId),
+			report.AreaOfAssignment,
+			report.NameOfChurch,
+		}
+		for _, activity := range model.Activities {
+			values := report.ActivityValues(activity.Key)
+			for week := 0; week < csvWeeks; week++ {
+				if week < len(values) {
+					record = append(record, strconv.Itoa(values[week]))
+				} else {
+					record = append(record, "")
+				}
+			}
+		}
+		for week := 0; week < csvWeeks; week++ {
+			if week < len(report.TithesAndOfferings) {
+				record = append(record, report.TithesAndOfferings[week].String())
+			} else {
+				record = append(record, "")
+			}
+		}
+		record = append(record,
+			report.Currency,
+			FormatPeople(report.People),
+			report.NarrativeReport,
+			report.ChallengesAndProblemEncountered,
+			report.PrayerRequest,
+		)
+
+		if err := writer.Write(record); err != nil {
+			return err
+		}
+	}
+
+	writer.Flush()
+	return writer.Error()
+}
+
+// ReadReportsCSV reads a file written by WriteReportsCSV. Columns may come in
+// any order and unknown columns are ignored; id, worker_name,
+// area_of_assignment and name_of_church are informational. Rows are numbered
+// as in a spreadsheet, the header being row 1.
+func ReadReportsCSV(r io.Reader) ([]*request.ReportImportRow, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, errors.New("the file is empty")
+		}
+		return nil, err
+	}
+
+	columns := map[string]int{}
+	for i, name := range header {
+		columns[strings.ToLower(strings.TrimSpace(strings.TrimPrefix(name, "\ufeff")))] = i
+	}
+	for _, required := range []string{"month_of", "church_id"} {
+		if _, ok := columns[required]; !ok {
+			return nil, fmt.Errorf("missing column %s", required)
+		}
+	}
+
+	var rows []*request.ReportImportRow
+	for line := 2; ; line++ {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		get := func(name string) string {
+			if i, ok := columns[name]; ok && i < len(record) {
+				return strings.TrimSpace(record[i])
+			}
+			return ""
+		}
+
+		if strings.Join(record, "") == "" {
+			continue // Skip blank lines
+		}
+
+		rows = append(rows, parseReportCSVRow(line, get))
+	}
+
+	return rows, nil
+}
+
+// parseReportCSVRow builds the create request the same way a JSON body is
+// bound, so the import goes through the same validation as the API
+func parseReportCSVRow(line int, get func(name string) string) *request.ReportImportRow {
+	row := &request.ReportImportRow{Row: line, WorkerName: get("worker_name")}
+	fields := map[string]interface{}{}
+
+	if value := get("month_of"); value != "" {
+		period, err := model.ParsePeriod(value)
+		if err != nil {
+			row.Errors = append(row.Errors, "month_of: "+err.Error())
+		}
+		fields["month_of"] = period
+	}
+
+	for _, name := range []string{"worker_id", "church_id"} {
+		value := get(name)
+		if value == "" {
+			continue
+		}
+		id, err := strconv.Atoi(value)
+		if err != nil {
+			row.Errors = append(row.Errors, name+": must be a whole number")
+			continue
+		}
+		fields[name] = id
+	}
+
+	for _, activity := range model.Activities {
+		var values []int
+		for week := 1; week <= csvWeeks; week++ {
+			column := csvWeekColumn(activity.Key, week)
+			value := get(column)
+			if value == "" {
+				values = append(values, 0)
+				continue
+			}
+			n, err := strconv.Atoi(value)
+			if err != nil || n < 0 {
+				row.Errors = append(row.Errors, column+": must be a whole number")
+			}
+			values = append(values, n)
+		}
+
+		// Trailing blank weeks are weeks the month does not have
+		for len(values) > 0 && values[len(values)-1] == 0 && get(csvWeekColumn(activity.Key, len(values))) == "" {
+			values = values[:len(values)-1]
+		}
+		fields[activity.Key] = values
+	}
+
+	var amounts []model.Money
+	for week := 1; week <= csvWeeks; week++ {
+		column := csvWeekColumn("tithes_and_offerings", week)
+		value := get(column)
+		if value == "" {
+			amounts = append(amounts, 0)
+			continue
+		}
+		amount, err := model.ParseMoney(value)
+		if err != nil {
+			row.Errors = append(row.Errors, column+": "+err.Error())
+		}
+		amounts = append(amounts, amount)
+	}
+	for len(amounts) > 0 && amounts[len(amounts)-1] == 0 && get(csvWeekColumn("tithes_and_offerings", len(amounts))) == "" {
+		amounts = amounts[:len(amounts)-1]
+	}
+	fields["tithes_and_offerings"] = amounts
+	fields["currency"] = strings.ToUpper(get("currency"))
+
+	// Files exported before people had categories have a names column
+	people := get("people")
+	if people == "" {
+		people = strings.ReplaceAll(get("names"), ";", ",")
+	}
+	if people != "" {
+		fields["people"] = ParsePeople(people)
+	}
+
+	for _, name := range []string{"narrative_report", "challenges_and_problem_encountered", "prayer_request"} {
+		fields[name] = get(name)
+	}
+
+	report, err := reportRequestFromFields(fields)
+	if err != nil {
+		row.Errors = append(row.Errors, err.Error())
+	}
+	row.Report = report
+
+	return row
+}
+
+// reportRequestFromFields binds fields keyed by their JSON names, as a
+// request body would be
+func reportRequestFromFields(fields map[string]interface{}) (*request.ReportCreateRequest, error) {
+	body, err := json.Marshal(fields)
+	if err != nil {
+		return nil, err
+	}
+
+	var report request.ReportCreateRequest
+	if err := json.Unmarshal(body, &report); err != nil {
+		return nil, err
+	}
+
+	return &report, nil
+}
@@ -0,0 +1,140 @@
+package utils
+
+import (
+	"bytes"
+	"reflect"
+	"reports/data/response"
+	"reports/model"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestReportsCSVRoundTrip(t *testing.T) {
+	report := &response.ReportResponse{
+		Id:                 7,
+		MonthOf:            model.NewPeriod(2024, time.March),
+		WorkerId:           3,
+		WorkerName:         "Juan Cruz",
+		ChurchId:           12,
+		WorshipService:     []int{40, 42, 0, 45, 39},
+		SundaySchool:       []int{20, 21},
+		TithesAndOfferings: []model.Money{150025, 0, 99},
+		Currency:           "PHP",
+		People: []*model.ReportPerson{
+			{Name: "Maria Santos", Category: model.PersonLedToChrist, Contact: "0917 555 1234", Date: "2024-03-10"},
+			{Name: "Pedro Reyes", Category: model.PersonFollowedUp},
+		},
+		NarrativeReport:                 "A good month, \"by grace\"",
+		ChallengesAndProblemEncountered: "Rain, floods",
+		PrayerRequest:                   "Line one\nline two",
+	}
+
+	var buf bytes.Buffer
+	if err := WriteReportsCSV(&buf, []*response.ReportResponse{report}); err != nil {
+		t.Fatalf("WriteReportsCSV() error = %v", err)
+	}
+
+	rows, err := ReadReportsCSV(&buf)
+	if err != nil {
+		t.Fatalf("ReadReportsCSV() error = %v", err)
+	}
+	if len(rows) != 1 {
+		t.Fatalf("ReadReportsCSV() returned %d rows, want 1", len(rows))
+	}
+
+	row := rows[0]
+	if len(row.Errors) != 0 {
+		t.Fatalf("row errors = %v", row.Errors)
+	}
+	if row.Row != 2 || row.WorkerName != report.WorkerName {
+		t.Errorf("row = %d %q, want 2 %q", row.Row, row.WorkerName, report.WorkerName)
+	}
+
+	got := row.Report
+	if got.MonthOf != report.MonthOf || got.WorkerId != report.WorkerId || got.ChurchId != report.ChurchId {
+		t.Errorf("month, worker, church = %v %d %d, want %v %d %d", got.MonthOf, got.WorkerId, got.ChurchId, report.MonthOf, report.WorkerId, report.ChurchId)
+	}
+	if !reflect.DeepEqual(got.WorshipService, report.WorshipService) {
+		t.Errorf("worship_service = %v, want %v", got.WorshipService, report.WorshipService)
+	}
+	if !reflect.DeepEqual(got.SundaySchool, report.SundaySchool) {
+		t.Errorf("sunday_school = %v, want %v", got.SundaySchool, report.SundaySchool)
+	}
+	if len(got.PrayerMeetings) != 0 {
+		t.Errorf("prayer_meetings = %v, want none", got.PrayerMeetings)
+	}
+	if !reflect.DeepEqual(got.TithesAndOfferings, report.TithesAndOfferings) || got.Currency != report.Currency {
+		t.Errorf("tithes_and_offerings = %v %s, want %v %s", got.TithesAndOfferings, got.Currency, report.TithesAndOfferings, report.Currency)
+	}
+	if !reflect.DeepEqual(got.People, report.People) {
+		t.Errorf("people = %v, want %v", FormatPeople(got.People), FormatPeople(report.People))
+	}
+	if got.NarrativeReport != report.NarrativeReport || got.ChallengesAndProblemEncountered != report.ChallengesAndProblemEncountered || got.PrayerRequest != report.PrayerRequest {
+		t.Errorf("texts = %q %q %q", got.NarrativeReport, got.ChallengesAndProblemEncountered, got.PrayerRequest)
+	}
+}
+
+func TestReadReportsCSVErrors(t *testing.T) {
+	tests := []struct {
+		name       string
+		file       string
+		wantErr    bool
+		wantRows   int
+		wantErrors []string
+	}{
+		{name: "empty file", file: "", wantErr: true},
+		{name: "missing church_id", file: "month_of,worker_id\n2024-03,1\n", wantErr: true},
+		{name: "blank lines are skipped", file: "month_of,church_id\n\n2024-03,1\n,\n", wantRows: 1},
+		{
+			name:       "bad month",
+			file:       "month_of,church_id\nsomeday,1\n",
+			wantRows:   1,
+			wantErrors: []string{"month_of: "},
+		},
+		{
+			name:       "bad church id",
+			file:       "month_of,church_id\n2024-03,twelve\n",
+			wantRows:   1,
+			wantErrors: []string{"church_id: must be a whole number"},
+		},
+		{
+			name:       "bad weekly value",
+			file:       "month_of,church_id,worship_service_week_1\n2024-03,1,-3\n",
+			wantRows:   1,
+			wantErrors: []string{"worship_service_week_1: must be a whole number"},
+		},
+		{
+			name:       "bad amount",
+			file:       "month_of,church_id,tithes_and_offerings_week_2\n2024-03,1,12.345\n",
+			wantRows:   1,
+			wantErrors: []string{"tithes_and_offerings_week_2: "},
+		},
+	}
+
+	for _, tt := range tests {
+		rows, err := ReadReportsCSV(strings.NewReader(tt.file))
+		if (err != nil) != tt.wantErr {
+			t.Errorf("%s: ReadReportsCSV() error = %v, wantErr %v", tt.name, err, tt.wantErr)
+			continue
+		}
+		if len(rows) != tt.wantRows {
+			t.Errorf("%s: got %d rows, want %d", tt.name, len(rows), tt.wantRows)
+			continue
+		}
+		if tt.wantRows == 0 {
+			continue
+		}
+
+		errors := rows[0].Errors
+		if len(errors) != len(tt.wantErrors) {
+			t.Errorf("%s: row errors = %v, want %v", tt.name, errors, tt.wantErrors)
+			continue
+		}
+		for i, prefix := range tt.wantErrors {
+			if !strings.HasPrefix(errors[i], prefix) {
+				t.Errorf("%s: row error %q, want prefix %q", tt.name, errors[i], prefix)
+			}
+		}
+	}
+}
@@ -3,8 +3,8 @@ package utils
 import (
 	"fmt"
 	"reports/data/response"
+	"reports/model"
 	"strconv"
-	"strings"
 
 	"github.com/tealeg/xlsx"
 )
@@ -41,7 +41,7 @@ func AddReportToSheet(sheet *xlsx.Sheet, report *response.ReportResponse) {
 	}
 
 	// Add report data
-	AddRow(sheet, "Month Of:", report.MonthOf, 120)
+	AddRow(sheet, "Month Of:", report.MonthOf.Label(), 120)
 	AddRow(sheet, "Worker Name:", report.WorkerName, 120)
 	AddRow(sheet, "Area Of Assignment:", report.AreaOfAssignment, 120)
 	AddRow(sheet, "Name Of Church:", report.NameOfChurch, 120)
@@ -83,7 +83,10 @@ func AddReportToSheet(sheet *xlsx.Sheet, report *response.ReportResponse) {
 	AddActivityRow(sheet, "Leadership Training:", report.LeadershipTraining, report.LeadershipTrainingAvg)
 	AddActivityRow(sheet, "Others:", report.Others, report.OthersAvg)
 	AddActivityRow(sheet, "Family Days:", report.FamilyDays, report.FamilyDaysAvg)
-	AddActivityRow(sheet, "Tithes And Offerings:", report.TithesAndOfferings, report.TithesAndOfferingsAvg)
+
+	// Amounts are totalled rather than averaged
+	addAmountsHeader(sheet, report.Currency)
+	AddMoneyRow(sheet, "Tithes And Offerings:", report.TithesAndOfferings, report.TithesAndOfferingsTotal, report.Currency)
 
 	AddActivityRow(sheet, "Home Visited:", report.HomeVisited, report.HomeVisitedAvg)
 	AddActivityRow(sheet, "Bible Study Group Led:", report.BibleStudyOrGroupLed, report.BibleStudyOrGroupLedAvg)
@@ -92,12 +95,8 @@ func AddReportToSheet(sheet *xlsx.Sheet, report *response.ReportResponse) {
 	AddActivityRow(sheet, "Person Followed-Up:", report.PersonFollowedUp, report.PersonFollowedUpAvg)
 	AddActivityRow(sheet, "Person Led To Christ:", report.PersonLedToChrist, report.PersonLedToChristAvg)
 
-	// Add Names as a comma-separated list
-	if len(report.Names) > 0 {
-		AddRow(sheet, "Names:", strings.Join(report.Names, ", "), 120)
-	} else {
-		AddRow(sheet, "Names:", "", 120) // Handle case where Names array is empty
-	}
+	// Add the people reached, one cell as written by FormatPeople
+	AddRow(sheet, "People Reached:", FormatPeople(report.People), 120)
 
 	// Add narrative report
 	AddRow(sheet, "Narrative Report:", report.NarrativeReport, 120)
@@ -227,6 +226,51 @@ func AddActivityRow(sheet *xlsx.Sheet, activity string, values []int, average fl
 	avgCell.Value = fmt.Sprintf("%.2f", average)
 }
 
+// addAmountsHeader starts the money rows, whose last column is the total
+func addAmountsHeader(sheet *xlsx.Sheet, currency string) {
+	row := sheet.AddRow()
+	for _, value := range []string{"Amounts (" + currency + ")", "Week 1", "Week 2", "Week 3", "Week 4", "Week 5", "Total"} {
+		cell := row.AddCell()
+		cell.Value = value
+		cell.SetStyle(GetWeeklyAttendanceStyle())
+	}
+}
+
+// AddMoneyRow writes weekly amounts and their total as numbers formatted in
+// the currency
+func AddMoneyRow(sheet *xlsx.Sheet, label string, values []model.Money, total model.Money, currency string) {
+	row := sheet.AddRow()
+
+	labelCell := row.AddCell()
+	labelCell.Value = label
+	labelCell.SetStyle(GetBoldTextStyle())
+
+	format := currencyNumberFormat(currency)
+	for i := 0; i < 5; i++ {
+		cell := row.AddCell()
+		if i < len(values) {
+			cell.SetFloatWithFormat(values[i].Float64(), format)
+		}
+	}
+
+	row.AddCell().SetFloatWithFormat(total.Float64(), format)
+}
+
+// Symbols of the currencies the ministry is likely to see; others are
+// written after the amount as their code
+var currencySymbols = map[string]string{
+	"PHP": "₱",
+	"USD": "$",
+	"EUR": "€",
+}
+
+func currencyNumberFormat(currency string) string {
+	if symbol, ok := currencySymbols[currency]; ok {
+		return `"` + symbol + `"#,##0.00`
+	}
+	return `#,##0.00 "` + currency + `"`
+}
+
 func addCellWithStyle(row *xlsx.Row, value string, bold bool) {
 	cell := row.AddCell()
 	cell.Value = value
@@ -0,0 +1,94 @@
+package utils
+
+import (
+	"fmt"
+	"reports/model"
+	"strconv"
+	"time"
+
+	"github.com/tealeg/xlsx"
+)
+
+func AddAnnualReportToSheet(sheet *xlsx.Sheet, annual *model.AnnualReport) {
+	// Add organization name
+	orgNameCell := sheet.AddRow().AddCell()
+	orgNameCell.Value = "ANG MANANAMPALATAYANG GUMAWA"
+	orgNameCell.SetStyle(GetOrgNameStyle())
+	orgNameCell.HMerge = 13
+
+	// Add main title
+	titleCell := sheet.AddRow().AddCell()
+	titleCell.Value = "NATIONAL WORKERS' ANNUAL REPORT"
+	titleCell.SetStyle(GetTitleStyle())
+	titleCell.HMerge = 13
+
+	sheet.Col(0).Width = 35
+	for i := 1; i <= 14; i++ {
+		sheet.Col(i).Width = 12
+	}
+
+	AddRow(sheet, "Year:", strconv.Itoa(annual.Year), 120)
+	AddRow(sheet, "Through:", annual.Through.Label(), 120)
+	AddRow(sheet, "Worker Name:", annual.WorkerName, 120)
+	AddRow(sheet, "Reports Submitted:", strconv.Itoa(annual.ReportCount), 120)
+
+	addAnnualSection(sheet, "WEEKLY ATTENDANCE", model.KindAttendance, annual.Activities)
+	addAnnualSection(sheet, "OUTREACH", model.KindOutreach, annual.Activities)
+
+	// Yearly totals of the outreach counters
+	sheet.AddRow()
+	addSectionHeader(sheet, "YEARLY TOTALS", 13)
+	for _, total := range annual.OutreachTotals {
+		AddRow(sheet, total.Label+":", strconv.Itoa(total.Total), 120)
+	}
+	for _, currency := range sortedCurrencies(annual.Offerings) {
+		row := sheet.AddRow()
+		row.AddCell().Value = "Tithes And Offerings (" + currency + "):"
+		row.AddCell().SetFloatWithFormat(annual.Offerings[currency].Float64(), currencyNumberFormat(currency))
+	}
+
+	// Add narrative report
+	sheet.AddRow()
+	AddRow(sheet, "Narrative Report:", annual.NarrativeReport, 120)
+	AddRow(sheet, "Challenges/\nProblems encountered:", annual.ChallengesAndProblemEncountered, 120)
+	AddRow(sheet, "Prayer Requests:", annual.PrayerRequest, 120)
+}
+
+// addAnnualSection writes the monthly averages of every activity of the
+// given kind, one column per month
+func addAnnualSection(sheet *xlsx.Sheet, title string, kind model.ActivityKind, activities []*model.AnnualActivity) {
+	addSectionHeader(sheet, title, 13)
+
+	headerRow := sheet.AddRow()
+	headers := []string{"Activities"}
+	for month := time.January; month <= time.December; month++ {
+		headers = append(headers, month.String()[:3])
+	}
+	headers = append(headers, "Average")
+	for _, header := range headers {
+		cell := headerRow.AddCell()
+		cell.Value = header
+		cell.SetStyle(GetWeeklyAttendanceStyle())
+	}
+
+	for _, activity := range activities {
+		if activity.Kind != kind {
+			continue
+		}
+
+		row := sheet.AddRow()
+		labelCell := row.AddCell()
+		labelCell.Value = activity.Label + ":"
+		labelCell.SetStyle(GetBoldTextStyle())
+
+		for _, average := range activity.Monthly {
+			if average != nil {
+				row.AddCell().Value = fmt.Sprintf("%.2f", *average)
+			} else {
+				row.AddCell().Value = "" // Blank for months without a report
+			}
+		}
+
+		row.AddCell().Value = fmt.Sprintf("%.2f", activity.Average)
+	}
+}
@@ -0,0 +1,243 @@
+package utils
+
+import (
+	"math"
+	"reports/data/request"
+	"reports/model"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/tealeg/xlsx"
+)
+
+// Labels written by AddReportToSheet that differ from the activity label
+var sheetActivityAliases = map[string]string{
+	"biblestudygroupled":    "bible_study_or_group_led",
+	"sermonmessagepreached": "sermon_or_message_preached",
+}
+
+// ReadReportSheet reads a monthly report from a sheet laid out like
+// AddReportToSheet. Rows are recognised by their label in the first column,
+// so blank or extra rows do not matter. Anything that cannot be read is
+// listed in Errors with its cell.
+func ReadReportSheet(sheet *xlsx.Sheet) *request.ReportSheetImport {
+	result := &request.ReportSheetImport{Cells: map[string]string{}}
+	fields := map[string]interface{}{}
+
+	activities := map[string]model.Activity{}
+	for _, activity := range model.Activities {
+		activities[sheetLabel(activity.Label)] = activity
+	}
+	for label, key := range sheetActivityAliases {
+		activities[label], _ = model.FindActivity(key)
+	}
+
+	for y, row := range sheet.Rows {
+		if row == nil || len(row.Cells) == 0 {
+			continue
+		}
+
+		label := sheetLabel(row.Cells[0].Value)
+		if currency, ok := sheetCurrency(row.Cells[0].Value); ok {
+			fields["currency"] = currency
+			continue
+		}
+		value := func(x int) string {
+			if x < len(row.Cells) {
+				return strings.TrimSpace(row.Cells[x].Value)
+			}
+			return ""
+		}
+		cell := xlsx.GetCellIDStringFromCoords(1, y)
+
+		switch label {
+		case "monthof":
+			result.Cells["month_of"] = cell
+			period, err := readSheetPeriod(row, 1)
+			if err != nil {
+				result.AddError("month_of", "cannot read the month: "+err.Error())
+				continue
+			}
+			fields["month_of"] = period
+		case "workername":
+			result.Cells["worker_name"] = cell
+			result.WorkerName = value(1)
+		case "areaofassignment":
+			result.Cells["area_of_assignment"] = cell
+			result.AreaName = value(1)
+		case "nameofchurch":
+			result.Cells["name_of_church"] = cell
+			result.ChurchName = value(1)
+		case "peoplereached", "names":
+			// Older sheets have a comma-separated "Names:" row instead
+			result.Cells["people"] = cell
+			fields["people"] = ParsePeople(value(1))
+		case "narrativereport":
+			result.Cells["narrative_report"] = cell
+			fields["narrative_report"] = unwrapSheetText(value(1))
+		case "challengesproblemsencountered":
+			result.Cells["challenges_and_problem_encountered"] = cell
+			fields["challenges_and_problem_encountered"] = unwrapSheetText(value(1))
+		case "prayerrequests":
+			result.Cells["prayer_request"] = cell
+			fields["prayer_request"] = unwrapSheetText(value(1))
+		case "tithesandofferings":
+			result.Cells["tithes_and_offerings"] = cell
+
+			var amounts []model.Money
+			for week := 1; week <= 5; week++ {
+				amount, err := readSheetMoney(value(week))
+				if err != nil {
+					result.Errors = append(result.Errors, &model.CellError{
+						Cell:    xlsx.GetCellIDStringFromCoords(week, y),
+						Field:   "tithes_and_offerings",
+						Message: "week " + strconv.Itoa(week) + " " + err.Error(),
+					})
+				}
+				amounts = append(amounts, amount)
+			}
+			for len(amounts) > 0 && value(len(amounts)) == "" {
+				amounts = amounts[:len(amounts)-1]
+			}
+			fields["tithes_and_offerings"] = amounts
+		default:
+			activity, ok := activities[label]
+			if !ok {
+				continue
+			}
+			result.Cells[activity.Key] = cell
+
+			var weeks []int
+			for week := 1; week <= 5; week++ {
+				n, err := readSheetCount(value(week))
+				if err != nil {
+					result.Errors = append(result.Errors, &model.CellError{
+						Cell:    xlsx.GetCellIDStringFromCoords(week, y),
+						Field:   activity.Key,
+						Message: "week " + strconv.Itoa(week) + " must be a whole number",
+					})
+				}
+				weeks = append(weeks, n)
+			}
+
+			// Trailing blank weeks are weeks the month does not have
+			for len(weeks) > 0 && value(len(weeks)) == "" {
+				weeks = weeks[:len(weeks)-1]
+			}
+			fields[activity.Key] = weeks
+		}
+	}
+
+	for _, field := range []string{"month_of", "worker_name", "name_of_church"} {
+		if _, ok := result.Cells[field]; !ok {
+			result.AddError(field, "row not found; is this a monthly report sheet?")
+		}
+	}
+
+	report, err := reportRequestFromFields(fields)
+	if err != nil {
+		result.AddError("report", err.Error())
+		report = &request.ReportCreateRequest{}
+	}
+	result.Report = report
+
+	return result
+}
+
+// sheetLabel reduces a label such as "Sermon/\nMessage Preached:" to
+// "sermonmessagepreached"
+func sheetLabel(label string) string {
+	return strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			return unicode.ToLower(r)
+		}
+		return -1
+	}, label)
+}
+
+// readSheetPeriod accepts the "January 2024" label written by the export or
+// a date typed into the cell
+func readSheetPeriod(row *xlsx.Row, x int) (model.Period, error) {
+	if x >= len(row.Cells) {
+		return model.ParsePeriod("")
+	}
+
+	cell := row.Cells[x]
+	if cell.IsTime() || cell.Type() == xlsx.CellTypeNumeric {
+		if t, err := cell.GetTime(false); err == nil {
+			return model.PeriodOf(t), nil
+		}
+	}
+
+	return model.ParsePeriod(strings.TrimSpace(cell.Value))
+}
+
+// readSheetCount parses a weekly count; Excel may store it as "12" or "12.0"
+func readSheetCount(value string) (int, error) {
+	if value == "" {
+		return 0, nil
+	}
+
+	n, err := strconv.ParseFloat(value, 64)
+	if err != nil || n < 0 || n != math.Trunc(n) {
+		return 0, strconv.ErrSyntax
+	}
+
+	return int(n), nil
+}
+
+// sheetCurrency reads the currency from the "Amounts (PHP)" header written
+// by AddReportToSheet
+func sheetCurrency(label string) (string, bool) {
+	label = strings.TrimSpace(label)
+	if !strings.HasPrefix(strings.ToLower(label), "amounts (") || !strings.HasSuffix(label, ")") {
+		return "", false
+	}
+	return strings.ToUpper(label[len("amounts (") : len(label)-1]), true
+}
+
+// readSheetMoney parses a weekly amount. Excel keeps numbers as floats, so
+// a value with float noise such as "1500.1000000001" is rounded to centavos.
+func readSheetMoney(value string) (model.Money, error) {
+	if value == "" {
+		return 0, nil
+	}
+
+	amount, err := model.ParseMoney(value)
+	if err == nil {
+		return amount, nil
+	}
+
+	n, floatErr := strconv.ParseFloat(value, 64)
+	if floatErr != nil || math.Abs(n) > 1e13 || math.Abs(n*100-math.Round(n*100)) > 1e-6 {
+		return 0, err
+	}
+	return model.Money(math.Round(n * 100)), nil
+}
+
+// unwrapSheetText removes the line breaks AddRow inserts after every 120th
+// byte of long values, keeping the ones typed by the worker
+func unwrapSheetText(value string) string {
+	const maxCharactersPerCell = 120
+
+	// offset is the byte index of the rune in the original value
+	var unwrapped strings.Builder
+	offset := 0
+	for pos := 0; pos < len(value); {
+		r, size := utf8.DecodeRuneInString(value[pos:])
+		unwrapped.WriteRune(r)
+		pos += size
+
+		if (offset+1)%maxCharactersPerCell == 0 && pos < len(value) && value[pos] == '\n' {
+			pos++
+		}
+		offset += size
+	}
+
+	if unwrapped.Len() <= maxCharactersPerCell {
+		return value
+	}
+	return unwrapped.String()
+}
@@ -0,0 +1,80 @@
+package utils
+
+import (
+	"reports/model"
+	"strings"
+	"testing"
+
+	"github.com/tealeg/xlsx"
+)
+
+// wrapLikeExport runs a value through AddRow, as the exported workbook does
+func wrapLikeExport(t *testing.T, value string) string {
+	t.Helper()
+
+	sheet, err := xlsx.NewFile().AddSheet("Report")
+	if err != nil {
+		t.Fatal(err)
+	}
+	AddRow(sheet, "Narrative Report:", value, 120)
+	return sheet.Rows[0].Cells[1].Value
+}
+
+func TestUnwrapSheetText(t *testing.T) {
+	tests := []struct {
+		name  string
+		value string
+	}{
+		{name: "empty", value: ""},
+		{name: "short", value: "A good month"},
+		{name: "short with typed line breaks", value: "First line\nsecond line\n"},
+		{name: "exactly one cell width", value: strings.Repeat("a", 120)},
+		{name: "one past the width", value: strings.Repeat("a", 121)},
+		{name: "two widths", value: strings.Repeat("ab", 120)},
+		{name: "long", value: strings.Repeat("The church met every Sunday. ", 30)},
+		{name: "typed line break at the wrap", value: strings.Repeat("a", 120) + "\n" + strings.Repeat("b", 130)},
+		{name: "typed line breaks elsewhere", value: strings.Repeat("word ", 30) + "\n\n" + strings.Repeat("more ", 40)},
+		{name: "multibyte", value: strings.Repeat("Salamat sa Diyos — ", 20)},
+		{name: "multibyte across the wrap", value: strings.Repeat("a", 119) + "ñ" + strings.Repeat("b", 150)},
+	}
+
+	for _, tt := range tests {
+		wrapped := wrapLikeExport(t, tt.value)
+		if got := unwrapSheetText(wrapped); got != tt.value {
+			t.Errorf("%s: unwrapSheetText(AddRow(%q)) = %q", tt.name, tt.value, got)
+		}
+	}
+}
+
+func TestReadSheetMoney(t *testing.T) {
+	tests := []struct {
+		value   string
+		want    model.Money
+		wantErr bool
+	}{
+		{value: "", want: 0},
+		{value: "1500", want: 150000},
+		{value: "1,500.25", want: 150025},
+		// Excel stores numbers as floats; noise is rounded to centavos
+		{value: "1500.1000000001", want: 150010},
+		{value: "0.29999999999999999", want: 30},
+		{value: "-12.349999999999", want: -1235},
+		{value: "1.5e3", want: 150000},
+		// Real fractions of a centavo are rejected, not rounded
+		{value: "12.345", wantErr: true},
+		{value: "0.001", wantErr: true},
+		{value: "1e14", wantErr: true},
+		{value: "twelve", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		got, err := readSheetMoney(tt.value)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("readSheetMoney(%q) error = %v, wantErr %v", tt.value, err, tt.wantErr)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("readSheetMoney(%q) = %d, want %d", tt.value, got, tt.want)
+		}
+	}
+}
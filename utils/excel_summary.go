@@ -0,0 +1,165 @@
+package utils
+
+import (
+	"fmt"
+	"reports/model"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/tealeg/xlsx"
+)
+
+// Outreach totals shown for every church in the breakdown table
+var summaryChurchTotals = []string{"home_visited", "sermon_or_message_preached", "person_led_to_christ"}
+
+func AddAreaSummaryToSheet(sheet *xlsx.Sheet, summary *model.AreaSummary) {
+	const weeks = 5
+
+	// Add organization name
+	orgNameCell := sheet.AddRow().AddCell()
+	orgNameCell.Value = "ANG MANANAMPALATAYANG GUMAWA"
+	orgNameCell.SetStyle(GetOrgNameStyle())
+	orgNameCell.HMerge = 7
+
+	// Add main title
+	titleCell := sheet.AddRow().AddCell()
+	titleCell.Value = "AREA MONTHLY SUMMARY"
+	titleCell.SetStyle(GetTitleStyle())
+	titleCell.HMerge = 7
+
+	sheet.Col(0).Width = 35
+	for i := 1; i <= 20; i++ {
+		sheet.Col(i).Width = 15
+	}
+
+	AddRow(sheet, "Month Of:", summary.MonthOf.Label(), 120)
+	AddRow(sheet, "Area Of Assignment:", summary.AreaName, 120)
+	AddRow(sheet, "Churches Reporting:", strconv.Itoa(len(summary.Churches)), 120)
+	AddRow(sheet, "Reports:", strconv.Itoa(summary.ReportCount), 120)
+	for _, currency := range sortedCurrencies(summary.Offerings) {
+		row := sheet.AddRow()
+		row.AddCell().Value = "Tithes And Offerings (" + currency + "):"
+		row.AddCell().SetFloatWithFormat(summary.Offerings[currency].Float64(), currencyNumberFormat(currency))
+	}
+
+	addSummarySection(sheet, "WEEKLY ATTENDANCE", model.KindAttendance, summary.Activities, weeks)
+	addSummarySection(sheet, "OUTREACH", model.KindOutreach, summary.Activities, weeks)
+
+	// Per-church breakdown: average attendance and the main outreach totals
+	sheet.AddRow()
+	addSectionHeader(sheet, "CHURCH BREAKDOWN", 7)
+
+	headerRow := sheet.AddRow()
+	headers := []string{"Church", "Workers"}
+	for _, activity := range model.Activities {
+		if activity.Kind == model.KindAttendance {
+			headers = append(headers, activity.Label+" Avg")
+		}
+	}
+	for _, key := range summaryChurchTotals {
+		activity, _ := model.FindActivity(key)
+		headers = append(headers, activity.Label+" Total")
+	}
+	headers = append(headers, "Tithes And Offerings")
+	for _, header := range headers {
+		cell := headerRow.AddCell()
+		cell.Value = header
+		cell.SetStyle(GetWeeklyAttendanceStyle())
+	}
+
+	for _, church := range summary.Churches {
+		row := sheet.AddRow()
+		nameCell := row.AddCell()
+		nameCell.Value = church.Name
+		nameCell.SetStyle(GetBoldTextStyle())
+		row.AddCell().Value = strings.Join(church.WorkerNames, ", ")
+
+		for _, activity := range church.Activities {
+			if activity.Kind == model.KindAttendance {
+				row.AddCell().Value = fmt.Sprintf("%.2f", activity.Average)
+			}
+		}
+		for _, key := range summaryChurchTotals {
+			for _, activity := range church.Activities {
+				if activity.Key == key {
+					row.AddCell().SetInt(activity.Total)
+				}
+			}
+		}
+		addOfferingsCell(row, church.Offerings)
+	}
+}
+
+// addOfferingsCell writes a total as a currency number, or as text when the
+// reports used several currencies
+func addOfferingsCell(row *xlsx.Row, offerings map[string]model.Money) {
+	currencies := sortedCurrencies(offerings)
+	cell := row.AddCell()
+	if len(currencies) == 1 {
+		cell.SetFloatWithFormat(offerings[currencies[0]].Float64(), currencyNumberFormat(currencies[0]))
+		return
+	}
+
+	var totals []string
+	for _, currency := range currencies {
+		totals = append(totals, currency+" "+offerings[currency].Grouped())
+	}
+	cell.Value = strings.Join(totals, "; ")
+}
+
+func sortedCurrencies(offerings map[string]model.Money) []string {
+	currencies := make([]string, 0, len(offerings))
+	for currency := range offerings {
+		currencies = append(currencies, currency)
+	}
+	sort.Strings(currencies)
+	return currencies
+}
+
+func addSectionHeader(sheet *xlsx.Sheet, title string, hmerge int) {
+	cell := sheet.AddRow().AddCell()
+	cell.Value = title
+	cell.SetStyle(GetWeeklyAttendanceHeaderStyle())
+	cell.HMerge = hmerge
+}
+
+// addSummarySection writes the weekly sums, total and average of every
+// activity of the given kind
+func addSummarySection(sheet *xlsx.Sheet, title string, kind model.ActivityKind, activities []*model.ActivitySummary, weeks int) {
+	addSectionHeader(sheet, title, weeks+2)
+
+	headerRow := sheet.AddRow()
+	headers := []string{"Activities"}
+	for i := 1; i <= weeks; i++ {
+		headers = append(headers, "Week "+strconv.Itoa(i))
+	}
+	headers = append(headers, "Total", "Average")
+	for _, header := range headers {
+		cell := headerRow.AddCell()
+		cell.Value = header
+		cell.SetStyle(GetWeeklyAttendanceStyle())
+	}
+
+	for _, activity := range activities {
+		if activity.Kind != kind {
+			continue
+		}
+
+		row := sheet.AddRow()
+		labelCell := row.AddCell()
+		labelCell.Value = activity.Label + ":"
+		labelCell.SetStyle(GetBoldTextStyle())
+
+		for i := 0; i < weeks; i++ {
+			if i < len(activity.Weeks) {
+				row.AddCell().SetInt(activity.Weeks[i])
+			} else {
+				row.AddCell().Value = "" // Blank for weeks with no data
+			}
+		}
+
+		row.AddCell().SetInt(activity.Total)
+		row.AddCell().Value = fmt.Sprintf("%.2f", activity.Average)
+	}
+}
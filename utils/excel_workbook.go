@@ -0,0 +1,134 @@
+package utils
+
+import (
+	"reports/data/response"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/tealeg/xlsx"
+)
+
+const (
+	indexSheetName    = "Index"
+	maxSheetNameRunes = 31
+)
+
+// AddReportsToWorkbook writes an index sheet followed by one sheet per
+// worker. Every report of a worker is laid out with AddReportToSheet, one
+// below the other. The reports must already be grouped by worker.
+func AddReportsToWorkbook(file *xlsx.File, reports []*response.ReportResponse) error {
+	index, err := file.AddSheet(indexSheetName)
+	if err != nil {
+		return err
+	}
+	addIndexHeader(index)
+
+	used := map[string]bool{strings.ToLower(indexSheetName): true}
+
+	for start := 0; start < len(reports); {
+		end := start + 1
+		for end < len(reports) && reports[end].WorkerId == reports[start].WorkerId {
+			end++
+		}
+		workerReports := reports[start:end]
+		start = end
+
+		name := SheetName(workerReports[0].WorkerName, used)
+		sheet, err := file.AddSheet(name)
+		if err != nil {
+			return err
+		}
+
+		months := make([]string, 0, len(workerReports))
+		for i, report := range workerReports {
+			if i > 0 {
+				sheet.AddRow()
+			}
+			AddReportToSheet(sheet, report)
+			months = append(months, report.MonthOf.Label())
+		}
+
+		backRow := sheet.AddRow()
+		backRow.AddCell()
+		addSheetLink(backRow.AddCell(), indexSheetName, "Back to index")
+
+		row := index.AddRow()
+		addSheetLink(row.AddCell(), name, workerReports[0].WorkerName)
+		row.AddCell().Value = workerReports[0].AreaOfAssignment
+		row.AddCell().Value = strings.Join(months, ", ")
+		row.AddCell().SetInt(len(workerReports))
+	}
+
+	return nil
+}
+
+func addIndexHeader(sheet *xlsx.Sheet) {
+	orgNameCell := sheet.AddRow().AddCell()
+	orgNameCell.Value = "ANG MANANAMPALATAYANG GUMAWA"
+	orgNameCell.SetStyle(GetOrgNameStyle())
+	orgNameCell.HMerge = 3
+
+	titleCell := sheet.AddRow().AddCell()
+	titleCell.Value = "NATIONAL WORKERS' MONTHLY REPORT"
+	titleCell.SetStyle(GetTitleStyle())
+	titleCell.HMerge = 3
+
+	headerRow := sheet.AddRow()
+	for _, header := range []string{"Worker Name", "Area Of Assignment", "Months", "Reports"} {
+		cell := headerRow.AddCell()
+		cell.Value = header
+		cell.SetStyle(GetWeeklyAttendanceStyle())
+	}
+
+	sheet.Col(0).Width = 35
+	sheet.Col(1).Width = 25
+	sheet.Col(2).Width = 40
+	sheet.Col(3).Width = 10
+}
+
+// addSheetLink turns the cell into a link to the top of another sheet
+func addSheetLink(cell *xlsx.Cell, sheetName, label string) {
+	target := "#'" + strings.ReplaceAll(sheetName, "'", "''") + "'!A1"
+	cell.SetStringFormula(`HYPERLINK("` + target + `","` + strings.ReplaceAll(label, `"`, `""`) + `")`)
+	cell.Value = label
+
+	style := xlsx.NewStyle()
+	style.Font.Color = "FF0000FF"
+	style.Font.Underline = true
+	style.ApplyFont = true
+	cell.SetStyle(style)
+}
+
+// SheetName turns a worker name into a valid, unused sheet name. Excel
+// limits names to 31 characters, forbids : \ / ? * [ ] and compares them
+// case-insensitively.
+func SheetName(name string, used map[string]bool) string {
+	cleaned := strings.Map(func(r rune) rune {
+		switch r {
+		case ':', '\\', '/', '?', '*', '[', ']':
+			return ' '
+		}
+		return r
+	}, name)
+	cleaned = strings.Trim(strings.Join(strings.Fields(cleaned), " "), "'")
+	if cleaned == "" {
+		cleaned = "Worker"
+	}
+
+	candidate := truncateRunes(cleaned, maxSheetNameRunes)
+	for i := 2; used[strings.ToLower(candidate)]; i++ {
+		suffix := " (" + strconv.Itoa(i) + ")"
+		candidate = truncateRunes(cleaned, maxSheetNameRunes-len(suffix)) + suffix
+	}
+
+	used[strings.ToLower(candidate)] = true
+	return candidate
+}
+
+func truncateRunes(value string, limit int) string {
+	if utf8.RuneCountInString(value) <= limit {
+		return value
+	}
+	return strings.TrimSpace(string([]rune(value)[:limit]))
+}
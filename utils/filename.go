@@ -0,0 +1,12 @@
+package utils
+
+import "fmt"
+
+// ExportFileName names a download, adding the suffix (usually the month)
+// when present
+func ExportFileName(prefix, suffix, extension string) string {
+	if suffix == "" {
+		return fmt.Sprintf("%s.%s", prefix, extension)
+	}
+	return fmt.Sprintf("%s_%s.%s", prefix, suffix, extension)
+}
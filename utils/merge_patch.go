@@ -0,0 +1,26 @@
+package utils
+
+// MergePatch applies a JSON Merge Patch (RFC 7386) to a decoded JSON value.
+// Objects are merged key by key, a null removes the key, and anything else,
+// arrays included, replaces the target value whole.
+func MergePatch(target, patch interface{}) interface{} {
+	patchObject, ok := patch.(map[string]interface{})
+	if !ok {
+		return patch
+	}
+
+	targetObject, ok := target.(map[string]interface{})
+	if !ok {
+		targetObject = map[string]interface{}{}
+	}
+
+	for key, value := range patchObject {
+		if value == nil {
+			delete(targetObject, key)
+			continue
+		}
+		targetObject[key] = MergePatch(targetObject[key], value)
+	}
+
+	return targetObject
+}
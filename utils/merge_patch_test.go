@@ -0,0 +1,50 @@
+package utils
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+// The cases follow the examples of RFC 7386, appendix A
+func TestMergePatch(t *testing.T) {
+	tests := []struct {
+		name   string
+		target string
+		patch  string
+		want   string
+	}{
+		{name: "replace a value", target: `{"a":"b"}`, patch: `{"a":"c"}`, want: `{"a":"c"}`},
+		{name: "add a key", target: `{"a":"b"}`, patch: `{"b":"c"}`, want: `{"a":"b","b":"c"}`},
+		{name: "null removes a key", target: `{"a":"b"}`, patch: `{"a":null}`, want: `{}`},
+		{name: "null removes only its key", target: `{"a":"b","b":"c"}`, patch: `{"a":null}`, want: `{"b":"c"}`},
+		{name: "null on a missing key", target: `{"a":"b"}`, patch: `{"c":null}`, want: `{"a":"b"}`},
+		{name: "arrays are replaced whole", target: `{"a":[1,2,3]}`, patch: `{"a":[4]}`, want: `{"a":[4]}`},
+		{name: "an array replaces an object", target: `{"a":{"b":"c"}}`, patch: `{"a":[1]}`, want: `{"a":[1]}`},
+		{name: "an object replaces an array", target: `{"a":[1]}`, patch: `{"a":{"b":"c"}}`, want: `{"a":{"b":"c"}}`},
+		{name: "nested objects merge", target: `{"a":{"b":"c","d":"e"}}`, patch: `{"a":{"d":"f"}}`, want: `{"a":{"b":"c","d":"f"}}`},
+		{name: "nested null removes a nested key", target: `{"a":{"b":"c","d":"e"}}`, patch: `{"a":{"b":null}}`, want: `{"a":{"d":"e"}}`},
+		{name: "nested object into a scalar", target: `{"a":"b"}`, patch: `{"a":{"c":{"d":null}}}`, want: `{"a":{"c":{}}}`},
+		{name: "patch that is not an object replaces", target: `{"a":"b"}`, patch: `["c"]`, want: `["c"]`},
+		{name: "null patch replaces", target: `{"a":"b"}`, patch: `null`, want: `null`},
+		{name: "object patch on a scalar target", target: `"text"`, patch: `{"a":1}`, want: `{"a":1}`},
+		{name: "empty patch leaves the target", target: `{"a":"b"}`, patch: `{}`, want: `{"a":"b"}`},
+	}
+
+	for _, tt := range tests {
+		var target, patch, want interface{}
+		for _, doc := range []struct {
+			text string
+			into *interface{}
+		}{{tt.target, &target}, {tt.patch, &patch}, {tt.want, &want}} {
+			if err := json.Unmarshal([]byte(doc.text), doc.into); err != nil {
+				t.Fatalf("%s: %v", tt.name, err)
+			}
+		}
+
+		if got := MergePatch(target, patch); !reflect.DeepEqual(got, want) {
+			gotJSON, _ := json.Marshal(got)
+			t.Errorf("%s: MergePatch(%s, %s) = %s, want %s", tt.name, tt.target, tt.patch, gotJSON, tt.want)
+		}
+	}
+}
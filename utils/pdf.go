@@ -0,0 +1,187 @@
+package utils
+
+import (
+	"fmt"
+	"io"
+	"reports/data/response"
+	"reports/model"
+	"strconv"
+	"strings"
+
+	"github.com/go-pdf/fpdf"
+)
+
+// Column widths of the weekly grid in millimetres; they add up to the 180mm
+// between the margins of an A4 page
+const (
+	pdfActivityWidth = 60
+	pdfWeekWidth     = 18
+	pdfAverageWidth  = 30
+	pdfRowHeight     = 6
+)
+
+// WriteReportPDF renders a monthly report for printing and signing, in the
+// same order as AddReportToSheet
+func WriteReportPDF(w io.Writer, report *response.ReportResponse) error {
+	pdf := fpdf.New("P", "mm", "A4", "")
+	pdf.SetMargins(15, 15, 15)
+	pdf.SetAutoPageBreak(true, 15)
+	pdf.AliasNbPages("")
+	pdf.AddPage()
+
+	// The core fonts are not UTF-8; names such as "Niño" need translating
+	tr := pdf.UnicodeTranslatorFromDescriptor("")
+
+	pdf.SetFooterFunc(func() {
+		pdf.SetY(-12)
+		pdf.SetFont("Helvetica", "I", 8)
+		pdf.CellFormat(0, 5, fmt.Sprintf("Page %d of {nb}", pdf.PageNo()), "", 0, "R", false, 0, "")
+	})
+
+	// Add organization name
+	pdf.SetFont("Helvetica", "B", 15)
+	pdf.SetTextColor(0, 0, 255)
+	pdf.CellFormat(0, 8, "ANG MANANAMPALATAYANG GUMAWA", "", 1, "L", false, 0, "")
+	pdf.SetTextColor(0, 0, 0)
+
+	// Add main title
+	pdf.SetFont("Helvetica", "B", 13)
+	pdf.CellFormat(0, 8, "NATIONAL WORKERS' MONTHLY REPORT", "", 1, "L", false, 0, "")
+	pdf.Ln(2)
+
+	// Add report data
+	addPDFField(pdf, tr, "Month Of:", report.MonthOf.Label())
+	addPDFField(pdf, tr, "Worker Name:", report.WorkerName)
+	addPDFField(pdf, tr, "Area Of Assignment:", report.AreaOfAssignment)
+	addPDFField(pdf, tr, "Name Of Church:", report.NameOfChurch)
+	pdf.Ln(3)
+
+	addPDFActivityGrid(pdf, tr, "WEEKLY ATTENDANCE", model.KindAttendance, report)
+	addPDFOfferings(pdf, tr, report)
+	pdf.Ln(3)
+	addPDFActivityGrid(pdf, tr, "OUTREACH", model.KindOutreach, report)
+	pdf.Ln(3)
+
+	addPDFSection(pdf, tr, "People Reached:", describePeople(report.People))
+	addPDFSection(pdf, tr, "Narrative Report:", report.NarrativeReport)
+	addPDFSection(pdf, tr, "Challenges/Problems Encountered:", report.ChallengesAndProblemEncountered)
+	addPDFSection(pdf, tr, "Prayer Requests:", report.PrayerRequest)
+
+	// Signature lines
+	pdf.Ln(12)
+	pdf.SetFont("Helvetica", "", 10)
+	left, _, _, _ := pdf.GetMargins()
+	y := pdf.GetY()
+	pdf.Line(left, y, left+70, y)
+	pdf.Line(left+110, y, left+180, y)
+	pdf.CellFormat(110, 5, tr("Submitted by: "+report.WorkerName), "", 0, "L", false, 0, "")
+	pdf.CellFormat(70, 5, "Noted by: Pastor", "", 1, "L", false, 0, "")
+
+	return pdf.Output(w)
+}
+
+func addPDFField(pdf *fpdf.Fpdf, tr func(string) string, label, value string) {
+	pdf.SetFont("Helvetica", "B", 11)
+	pdf.CellFormat(45, pdfRowHeight, label, "", 0, "L", false, 0, "")
+	pdf.SetFont("Helvetica", "", 11)
+	pdf.CellFormat(0, pdfRowHeight, tr(value), "", 1, "L", false, 0, "")
+}
+
+// addPDFActivityGrid writes the weekly values and averages of every
+// activity of the given kind
+func addPDFActivityGrid(pdf *fpdf.Fpdf, tr func(string) string, title string, kind model.ActivityKind, report *response.ReportResponse) {
+	// Section header, yellow like GetWeeklyAttendanceHeaderStyle
+	pdf.SetFont("Helvetica", "B", 12)
+	pdf.SetFillColor(255, 255, 0)
+	pdf.CellFormat(0, 7, title, "", 1, "L", true, 0, "")
+
+	// Column headers, white on black like GetWeeklyAttendanceStyle
+	pdf.SetFont("Helvetica", "B", 10)
+	pdf.SetFillColor(0, 0, 0)
+	pdf.SetTextColor(255, 255, 255)
+	pdf.CellFormat(pdfActivityWidth, pdfRowHeight, "Activities", "1", 0, "L", true, 0, "")
+	for week := 1; week <= 5; week++ {
+		pdf.CellFormat(pdfWeekWidth, pdfRowHeight, "Week "+strconv.Itoa(week), "1", 0, "C", true, 0, "")
+	}
+	pdf.CellFormat(pdfAverageWidth, pdfRowHeight, "Average", "1", 1, "C", true, 0, "")
+	pdf.SetTextColor(0, 0, 0)
+
+	for _, activity := range model.Activities {
+		if activity.Kind != kind {
+			continue
+		}
+
+		values := report.ActivityValues(activity.Key)
+
+		pdf.SetFont("Helvetica", "B", 10)
+		pdf.CellFormat(pdfActivityWidth, pdfRowHeight, tr(activity.Label+":"), "1", 0, "L", false, 0, "")
+		pdf.SetFont("Helvetica", "", 10)
+		for week := 0; week < 5; week++ {
+			value := "" // Blank for weeks with no data
+			if week < len(values) {
+				value = strconv.Itoa(values[week])
+			}
+			pdf.CellFormat(pdfWeekWidth, pdfRowHeight, value, "1", 0, "C", false, 0, "")
+		}
+		pdf.CellFormat(pdfAverageWidth, pdfRowHeight, fmt.Sprintf("%.2f", model.CalculateAverage(values)), "1", 1, "C", false, 0, "")
+	}
+}
+
+// addPDFOfferings writes the weekly tithes and offerings under the
+// attendance grid, with their total where the other rows have an average
+func addPDFOfferings(pdf *fpdf.Fpdf, tr func(string) string, report *response.ReportResponse) {
+	pdf.SetFont("Helvetica", "B", 10)
+	pdf.SetFillColor(0, 0, 0)
+	pdf.SetTextColor(255, 255, 255)
+	pdf.CellFormat(pdfActivityWidth, pdfRowHeight, tr("Amounts ("+report.Currency+")"), "1", 0, "L", true, 0, "")
+	for week := 1; week <= 5; week++ {
+		pdf.CellFormat(pdfWeekWidth, pdfRowHeight, "Week "+strconv.Itoa(week), "1", 0, "C", true, 0, "")
+	}
+	pdf.CellFormat(pdfAverageWidth, pdfRowHeight, "Total", "1", 1, "C", true, 0, "")
+	pdf.SetTextColor(0, 0, 0)
+
+	pdf.SetFont("Helvetica", "B", 10)
+	pdf.CellFormat(pdfActivityWidth, pdfRowHeight, "Tithes And Offerings:", "1", 0, "L", false, 0, "")
+	pdf.SetFont("Helvetica", "", 9)
+	for week := 0; week < 5; week++ {
+		value := ""
+		if week < len(report.TithesAndOfferings) {
+			value = report.TithesAndOfferings[week].Grouped()
+		}
+		pdf.CellFormat(pdfWeekWidth, pdfRowHeight, value, "1", 0, "R", false, 0, "")
+	}
+	pdf.SetFont("Helvetica", "B", 10)
+	pdf.CellFormat(pdfAverageWidth, pdfRowHeight, report.TithesAndOfferingsTotal.Grouped(), "1", 1, "R", false, 0, "")
+}
+
+func addPDFSection(pdf *fpdf.Fpdf, tr func(string) string, label, text string) {
+	pdf.SetFont("Helvetica", "B", 11)
+	pdf.CellFormat(0, pdfRowHeight, label, "", 1, "L", false, 0, "")
+	pdf.SetFont("Helvetica", "", 10)
+	pdf.MultiCell(0, 5, tr(text), "1", "L", false)
+	pdf.Ln(2)
+}
+
+// describePeople lists the people reached one per line, as in
+// "Juan Cruz (Led To Christ, 0917 555 1234, 2024-03-10)"
+func describePeople(people []*model.ReportPerson) string {
+	lines := make([]string, 0, len(people))
+	for _, person := range people {
+		var details []string
+		if person.Category != "" {
+			details = append(details, person.Category.Label())
+		}
+		for _, detail := range []string{person.Contact, person.Date} {
+			if detail != "" {
+				details = append(details, detail)
+			}
+		}
+
+		line := person.Name
+		if len(details) > 0 {
+			line += " (" + strings.Join(details, ", ") + ")"
+		}
+		lines = append(lines, line)
+	}
+	return strings.Join(lines, "\n")
+}
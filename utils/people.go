@@ -0,0 +1,65 @@
+package utils
+
+import (
+	"reports/model"
+	"strings"
+)
+
+// FormatPeople writes the people of a report as text for a single cell:
+// entries are separated by "; " and their fields by " | ", as in
+// "Juan Cruz | led_to_christ | 0917 555 1234 | 2024-03-10". Empty trailing
+// fields are left out.
+func FormatPeople(people []*model.ReportPerson) string {
+	entries := make([]string, 0, len(people))
+	for _, person := range people {
+		fields := []string{person.Name, string(person.Category), person.Contact, person.Date}
+		for len(fields) > 1 && fields[len(fields)-1] == "" {
+			fields = fields[:len(fields)-1]
+		}
+		entries = append(entries, strings.Join(fields, " | "))
+	}
+	return strings.Join(entries, "; ")
+}
+
+// ParsePeople reads text written by FormatPeople. Categories may be spelled
+// as on the form ("Led To Christ"). A plain comma-separated list, as older
+// exports wrote the names, yields people without a category.
+func ParsePeople(text string) []*model.ReportPerson {
+	separator := ";"
+	if !strings.ContainsAny(text, ";|\n") {
+		separator = ","
+	}
+
+	var people []*model.ReportPerson
+	for _, entry := range strings.FieldsFunc(text, func(r rune) bool { return strings.ContainsRune(separator+"\n", r) }) {
+		fields := strings.Split(entry, "|")
+		for i := range fields {
+			fields[i] = strings.TrimSpace(fields[i])
+		}
+		if strings.Join(fields, "") == "" {
+			continue
+		}
+
+		person := &model.ReportPerson{Name: fields[0]}
+		if len(fields) > 1 {
+			person.Category = parsePersonCategory(fields[1])
+		}
+		if len(fields) > 2 {
+			person.Contact = fields[2]
+		}
+		if len(fields) > 3 {
+			person.Date = fields[3]
+		}
+		people = append(people, person)
+	}
+	return people
+}
+
+// parsePersonCategory accepts "led_to_christ", "Led To Christ" and
+// "led-to-christ" alike. Anything else is kept for validation to reject.
+func parsePersonCategory(value string) model.PersonCategory {
+	normalized := strings.ToLower(strings.Join(strings.FieldsFunc(value, func(r rune) bool {
+		return r == ' ' || r == '_' || r == '-'
+	}), "_"))
+	return model.PersonCategory(normalized)
+}
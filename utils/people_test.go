@@ -0,0 +1,93 @@
+package utils
+
+import (
+	"reflect"
+	"reports/model"
+	"testing"
+)
+
+func TestFormatPeopleRoundTrip(t *testing.T) {
+	tests := []struct {
+		name   string
+		people []*model.ReportPerson
+		text   string
+	}{
+		{name: "none", people: nil, text: ""},
+		{
+			name:   "name only",
+			people: []*model.ReportPerson{{Name: "Juan Cruz"}},
+			text:   "Juan Cruz",
+		},
+		{
+			name: "every field",
+			people: []*model.ReportPerson{
+				{Name: "Juan Cruz", Category: model.PersonLedToChrist, Contact: "0917 555 1234", Date: "2024-03-10"},
+				{Name: "Maria Santos", Category: model.PersonFollowedUp},
+			},
+			text: "Juan Cruz | led_to_christ | 0917 555 1234 | 2024-03-10; Maria Santos | followed_up",
+		},
+		{
+			name:   "empty field in the middle",
+			people: []*model.ReportPerson{{Name: "Pedro Reyes", Contact: "pedro@example.org"}},
+			text:   "Pedro Reyes |  | pedro@example.org",
+		},
+	}
+
+	for _, tt := range tests {
+		text := FormatPeople(tt.people)
+		if text != tt.text {
+			t.Errorf("%s: FormatPeople() = %q, want %q", tt.name, text, tt.text)
+		}
+		if got := ParsePeople(text); !reflect.DeepEqual(got, tt.people) {
+			t.Errorf("%s: ParsePeople(%q) = %q, want %q", tt.name, text, FormatPeople(got), tt.text)
+		}
+	}
+}
+
+func TestParsePeople(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		want []*model.ReportPerson
+	}{
+		{
+			name: "categories spelled as on the form",
+			text: "Juan | Led To Christ; Maria | newly-contacted; Pedro | HOME_VISITED",
+			want: []*model.ReportPerson{
+				{Name: "Juan", Category: model.PersonLedToChrist},
+				{Name: "Maria", Category: model.PersonNewlyContacted},
+				{Name: "Pedro", Category: model.PersonHomeVisited},
+			},
+		},
+		{
+			name: "older comma-separated names",
+			text: "Juan Cruz, Maria Santos ,Pedro",
+			want: []*model.ReportPerson{{Name: "Juan Cruz"}, {Name: "Maria Santos"}, {Name: "Pedro"}},
+		},
+		{
+			name: "one entry per line",
+			text: "Juan | followed_up\nMaria | led_to_christ\n",
+			want: []*model.ReportPerson{
+				{Name: "Juan", Category: model.PersonFollowedUp},
+				{Name: "Maria", Category: model.PersonLedToChrist},
+			},
+		},
+		{
+			name: "blank entries are skipped",
+			text: "; Juan ;; | ;",
+			want: []*model.ReportPerson{{Name: "Juan"}},
+		},
+		{
+			name: "unknown categories are kept for validation",
+			text: "Juan | visitor",
+			want: []*model.ReportPerson{{Name: "Juan", Category: "visitor"}},
+		},
+		{name: "empty", text: "  ", want: nil},
+	}
+
+	for _, tt := range tests {
+		if got := ParsePeople(tt.text); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s: ParsePeople(%q) = %q, want %q", tt.name, tt.text, FormatPeople(got), FormatPeople(tt.want))
+		}
+	}
+}
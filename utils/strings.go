@@ -0,0 +1,64 @@
+package utils
+
+import (
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// honorifics are dropped when comparing worker names
+var honorifics = map[string]bool{
+	"ptr": true, "pastor": true, "ps": true, "rev": true, "bro": true,
+	"sis": true, "mr": true, "mrs": true, "ms": true, "jr": true, "sr": true,
+}
+
+// NormalizeName lowercases a person's name, strips punctuation and
+// honorifics and collapses whitespace so spellings can be compared
+func NormalizeName(name string) string {
+	cleaned := strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			return unicode.ToLower(r)
+		}
+		return ' '
+	}, name)
+
+	var parts []string
+	for _, part := range strings.Fields(cleaned) {
+		if !honorifics[part] {
+			parts = append(parts, part)
+		}
+	}
+	return strings.Join(parts, " ")
+}
+
+// SortedNameKey returns the normalized name with its words sorted, so that
+// "Cruz, Juan" and "Juan Cruz" share a key
+func SortedNameKey(name string) string {
+	parts := strings.Fields(NormalizeName(name))
+	sort.Strings(parts)
+	return strings.Join(parts, " ")
+}
+
+// Levenshtein returns the edit distance between a and b
+func Levenshtein(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	prev := make([]int, len(rb)+1)
+	curr := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+
+	for i := 1; i <= len(ra); i++ {
+		curr[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+
+	return prev[len(rb)]
+}
@@ -0,0 +1,48 @@
+package utils
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// GenerateToken signs an HS256 token whose subject is the given user id
+func GenerateToken(ttl time.Duration, userId int, secret string) (string, time.Time, error) {
+	now := time.Now().UTC()
+	expiresAt := now.Add(ttl)
+
+	claims := jwt.RegisteredClaims{
+		Subject:   strconv.Itoa(userId),
+		ExpiresAt: jwt.NewNumericDate(expiresAt),
+		IssuedAt:  jwt.NewNumericDate(now),
+		NotBefore: jwt.NewNumericDate(now),
+	}
+
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString([]byte(secret))
+	if err != nil {
+		return "", time.Time{}, fmt.Errorf("generating token failed: %w", err)
+	}
+
+	return token, expiresAt, nil
+}
+
+// ValidateToken verifies the token signature and expiry and returns its user id
+func ValidateToken(token string, secret string) (int, error) {
+	claims := &jwt.RegisteredClaims{}
+	_, err := jwt.ParseWithClaims(token, claims, func(t *jwt.Token) (interface{}, error) {
+		return []byte(secret), nil
+	}, jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg()}))
+	if err != nil {
+		return 0, fmt.Errorf("invalid token: %w", err)
+	}
+
+	userId, err := strconv.Atoi(claims.Subject)
+	if err != nil {
+		return 0, errors.New("invalid token subject")
+	}
+
+	return userId, nil
+}